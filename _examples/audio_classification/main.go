@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	res, err := ic.AudioClassification(context.Background(), &huggingface.AudioClassificationRequest{
+		Filename: "sample.flac",
+		Model:    "superb/hubert-large-superb-er",
+		Parameters: huggingface.AudioClassificationParameters{
+			TopK: huggingface.PTR(3),
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, r := range res {
+		fmt.Printf("%s: %.4f\n", r.Label, r.Score)
+	}
+}
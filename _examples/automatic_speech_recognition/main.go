@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	res, err := ic.AutomaticSpeechRecognition(context.Background(), &huggingface.AutomaticSpeechRecognitionRequest{
+		Filename: "sample.flac",
+		Model:    "openai/whisper-large-v3",
+		Parameters: huggingface.AutomaticSpeechRecognitionParameters{
+			ReturnTimestamps: huggingface.ReturnTimestampsSegment,
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Text:", res.Text)
+
+	for _, chunk := range res.Chunks {
+		fmt.Printf("[%.2f - %.2f] %s\n", chunk.Timestamp[0], chunk.Timestamp[1], chunk.Text)
+	}
+}
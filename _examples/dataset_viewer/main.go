@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+type review struct {
+	Text  string `json:"text"`
+	Label int    `json:"label"`
+}
+
+func main() {
+	dc := huggingface.NewDatasetViewerClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	splits, err := dc.Splits(context.Background(), "stanfordnlp/imdb")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, split := range splits {
+		fmt.Printf("%s/%s\n", split.Config, split.Split)
+	}
+
+	it := dc.Rows(context.Background(), &huggingface.DatasetRowsRequest{
+		Dataset: "stanfordnlp/imdb",
+		Config:  "plain_text",
+		Split:   "test",
+		Limit:   250,
+	})
+
+	positive := 0
+
+	for it.Next() {
+		r := review{}
+		if err := it.Value().Decode(&r); err != nil {
+			log.Fatal(err)
+		}
+
+		positive += r.Label
+	}
+
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%d positive reviews\n", positive)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	res, err := ic.DocumentQuestionAnswering(context.Background(), &huggingface.DocumentQuestionAnsweringRequest{
+		Inputs: huggingface.DocumentQuestionAnsweringInputs{
+			Image:    huggingface.ImageFromFile("invoice.png"),
+			Question: "What is the invoice number?",
+		},
+		Model: "impira/layoutlm-document-qa",
+		Parameters: huggingface.DocumentQuestionAnsweringParameters{
+			TopK: huggingface.PTR(2),
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, r := range res {
+		fmt.Printf("%s: %.4f (words %d-%d)\n", r.Answer, r.Score, r.Start, r.End)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	hc := huggingface.NewHubClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	repoID := "Qwen/Qwen2.5-0.5B-Instruct-GGUF"
+
+	files, err := hc.ListGGUFFiles(context.Background(), repoID, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, file := range files {
+		metadata, err := hc.GGUFMetadata(context.Background(), repoID, file.Path, "")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s: %s %s, context length %d, vocab size %d, %d tensors, %d parameters\n",
+			file.Path, metadata.Architecture(), metadata.QuantizationType(), metadata.ContextLength(),
+			metadata.VocabSize(), len(metadata.Tensors), metadata.TotalParameters())
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	hc := huggingface.NewHubClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	it := hc.ListModels(context.Background(), &huggingface.ListModelsRequest{
+		PipelineTag: "text-classification",
+		Library:     "transformers",
+		Sort:        "downloads",
+		Limit:       5,
+	})
+
+	for it.Next() {
+		model := it.Value()
+		fmt.Printf("%s (%d downloads)\n", model.ID, model.Downloads)
+	}
+
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	info, err := hc.ModelInfo(context.Background(), "distilbert/distilbert-base-uncased-finetuned-sst-2-english", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, sibling := range info.Siblings {
+		fmt.Println(sibling.RFilename)
+	}
+}
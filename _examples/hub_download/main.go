@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	hc := huggingface.NewHubClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	path, err := hc.DownloadFile(context.Background(), "sentence-transformers/all-MiniLM-L6-v2", "tokenizer.json", "main")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(path)
+
+	dir, err := hc.SnapshotDownload(context.Background(), "sentence-transformers/all-MiniLM-L6-v2", "main", func(o *huggingface.SnapshotDownloadOptions) {
+		o.AllowPatterns = []string{"*.json", "onnx/model.onnx"}
+		o.Progress = func(p huggingface.DownloadProgress) {
+			fmt.Printf("\r%s: %d/%d bytes", p.Filename, p.Bytes, p.Size)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println()
+	fmt.Println(dir)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+type review struct {
+	Text  string `json:"text"`
+	Label int64  `json:"label"`
+}
+
+func main() {
+	hc := huggingface.NewHubClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	it := hc.ParquetRows(context.Background(), &huggingface.ParquetRowsRequest{
+		RepoID:  "stanfordnlp/imdb",
+		Config:  "plain_text",
+		Split:   "test",
+		Columns: []string{"text", "label"},
+		Offset:  12000,
+		Limit:   10,
+	})
+
+	for it.Next() {
+		r := review{}
+		if err := it.Value().Decode(&r); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%d: %d %.60q\n", it.Value().RowIdx, r.Label, r.Text)
+	}
+
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
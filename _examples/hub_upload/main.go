@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	hc := huggingface.NewHubClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	repo, err := hc.CreateRepo(context.Background(), &huggingface.CreateRepoRequest{
+		RepoID:  "your-name/your-model",
+		Private: true,
+		ExistOK: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(repo.URL)
+
+	commit, err := hc.CreateCommit(context.Background(), &huggingface.CreateCommitRequest{
+		RepoID: "your-name/your-model",
+		Operations: []huggingface.CommitOperation{
+			huggingface.AddFileFromBytes("README.md", []byte("# My model\n")),
+			huggingface.AddFileFromPath("model.safetensors", "model.safetensors"),
+		},
+		CommitMessage: "Upload model",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(commit.CommitURL)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	res, err := ic.ImageClassification(context.Background(), &huggingface.ImageClassificationRequest{
+		Inputs: huggingface.ImageFromURL("https://huggingface.co/datasets/huggingface/documentation-images/resolve/main/pipeline-cat-chonk.jpeg"),
+		Model:  "google/vit-base-patch16-224",
+		Parameters: huggingface.ImageClassificationParameters{
+			TopK: huggingface.PTR(3),
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, r := range res {
+		fmt.Printf("%s: %.4f\n", r.Label, r.Score)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	f, err := os.Open("street.jpg")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, err := ic.ImageSegmentation(context.Background(), &huggingface.ImageSegmentationRequest{
+		Inputs: huggingface.ImageFromImage(img),
+		Model:  "facebook/mask2former-swin-large-coco-panoptic",
+		Parameters: huggingface.ImageSegmentationParameters{
+			Subtask: "panoptic",
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, segment := range res {
+		fmt.Printf("%s: %.4f area=%d bounds=%v\n", segment.Label, segment.Score, segment.Area(), segment.Bounds())
+	}
+
+	out, err := os.Create("street_segments.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer out.Close()
+
+	if err := png.Encode(out, huggingface.OverlayMasks(img, res, 0.5)); err != nil {
+		log.Fatal(err)
+	}
+}
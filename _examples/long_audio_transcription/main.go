@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	f, err := os.Open("podcast.wav")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	audio, err := huggingface.DecodeWAV(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, err := ic.TranscribeLongAudio(context.Background(), &huggingface.LongAudioTranscriptionRequest{
+		Audio:          audio,
+		SplitOnSilence: true,
+		Model:          "openai/whisper-large-v3",
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(res.SRT())
+}
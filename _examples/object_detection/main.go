@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	f, err := os.Open("cats.jpg")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, err := ic.ObjectDetection(context.Background(), &huggingface.ObjectDetectionRequest{
+		Inputs: huggingface.ImageFromImage(img),
+		Model:  "facebook/detr-resnet-50",
+		Parameters: huggingface.ObjectDetectionParameters{
+			Threshold: huggingface.PTR(0.7),
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	objects := huggingface.NonMaxSuppression(res, 0.5, false)
+	for _, o := range objects {
+		fmt.Printf("%s: %.4f %+v\n", o.Label, o.Score, o.Box)
+	}
+
+	annotated := image.NewRGBA(img.Bounds())
+	draw.Draw(annotated, annotated.Bounds(), img, img.Bounds().Min, draw.Src)
+	huggingface.DrawDetectedObjects(annotated, objects)
+
+	out, err := os.Create("cats_annotated.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer out.Close()
+
+	if err := png.Encode(out, annotated); err != nil {
+		log.Fatal(err)
+	}
+}
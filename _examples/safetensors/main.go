@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	hc := huggingface.NewHubClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	metadata, err := hc.SafetensorsMetadata(context.Background(), "openai-community/gpt2", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for filename, file := range metadata.Files {
+		fmt.Println(filename)
+
+		for _, name := range file.TensorNames() {
+			tensor := file.Tensors[name]
+			fmt.Printf("  %s %s %v\n", name, tensor.DType, tensor.Shape)
+		}
+	}
+
+	fmt.Printf("%d parameters %v\n", metadata.TotalParameters(), metadata.ParameterCount)
+}
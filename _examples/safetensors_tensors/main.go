@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "safetensors")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "model.safetensors")
+
+	weight, err := huggingface.NewTensorF16("linear.weight", []int64{2, 2}, []float32{0.5, -1, 2, 0.25})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bias, err := huggingface.NewTensor("linear.bias", []int64{2}, []float32{0.1, 0.2})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := huggingface.WriteSafetensorsFile(name, []*huggingface.Tensor{weight, bias}, map[string]string{"format": "pt"}); err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := huggingface.OpenSafetensors(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer f.Close()
+
+	for _, name := range f.Metadata().TensorNames() {
+		tensor, err := f.Tensor(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		values, err := tensor.Float32()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s %s %v %v\n", tensor.Name, tensor.DType, tensor.Shape, values)
+	}
+}
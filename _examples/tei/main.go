@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	tei := huggingface.NewTEIClient(os.Getenv("TEI_ENDPOINT"), os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	embeddings, err := tei.Embed(context.Background(), &huggingface.TEIEmbedRequest{
+		Inputs: []string{"What is Deep Learning?"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Embedding:", embeddings[0][:8])
+
+	ranks, err := tei.Rerank(context.Background(), &huggingface.TEIRerankRequest{
+		Query:      "What is Deep Learning?",
+		Texts:      []string{"Deep Learning is not...", "Deep learning is..."},
+		ReturnText: huggingface.PTR(true),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, rank := range ranks {
+		fmt.Printf("%d %.4f %s\n", rank.Index, rank.Score, rank.Text)
+	}
+}
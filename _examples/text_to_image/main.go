@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	res, err := ic.TextToImageBatch(context.Background(), &huggingface.TextToImageRequest{
+		Inputs: "An astronaut riding a horse on the moon",
+		Model:  "stabilityai/stable-diffusion-xl-base-1.0",
+		Parameters: huggingface.TextToImageParameters{
+			NegativePrompt:    "blurry",
+			NumInferenceSteps: huggingface.PTR(30),
+		},
+		Options: huggingface.Options{
+			WaitForModel: huggingface.PTR(true),
+		},
+	}, []int64{1, 2, 3})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	paths, err := huggingface.WriteTextToImageResults("images", res)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Images:", paths)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	ic := huggingface.NewInferenceClient(os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	sentences := []string{"Welcome to our hotline.", "Please hold the line."}
+	clips := make([]*huggingface.Audio, 0, len(sentences))
+
+	for _, sentence := range sentences {
+		res, err := ic.TextToSpeech(context.Background(), &huggingface.TextToSpeechRequest{
+			Inputs: sentence,
+			Model:  "facebook/mms-tts-eng",
+			Options: huggingface.Options{
+				WaitForModel: huggingface.PTR(true),
+			},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		audio, err := res.Decode()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		clips = append(clips, audio)
+	}
+
+	prompt, err := huggingface.ConcatenateAudio(500*time.Millisecond, clips...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := prompt.WriteWAVFile("prompt.wav"); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	tgi := huggingface.NewTGIClient(os.Getenv("TGI_ENDPOINT"), os.Getenv("HUGGINGFACEHUB_API_TOKEN"))
+
+	if err := tgi.Health(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	info, err := tgi.Info(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Model:", info.ModelID)
+	fmt.Println("MaxInputTokens:", info.MaxInputTokens)
+
+	tokens, err := tgi.Tokenize(context.Background(), &huggingface.TGITokenizeRequest{
+		Inputs: "What is Deep Learning?",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Tokens:", len(tokens))
+
+	metrics, err := tgi.Metrics(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	queueSize, _ := metrics.Value("tgi_queue_size", nil)
+	fmt.Println("QueueSize:", queueSize)
+}
@@ -0,0 +1,281 @@
+package huggingface
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+// Audio represents mono PCM audio with samples in the range [-1, 1].
+type Audio struct {
+	// The number of samples per second.
+	SampleRate int
+
+	// The samples of the audio.
+	Samples []float32
+}
+
+// Duration returns the duration of the audio.
+func (a *Audio) Duration() time.Duration {
+	if a.SampleRate == 0 {
+		return 0
+	}
+
+	return time.Duration(len(a.Samples)) * time.Second / time.Duration(a.SampleRate)
+}
+
+// Slice returns the audio between the start and end sample indices.
+// The samples are shared with the original audio.
+func (a *Audio) Slice(start, end int) *Audio {
+	return &Audio{SampleRate: a.SampleRate, Samples: a.Samples[start:end]}
+}
+
+// offset returns the time offset of the sample index.
+func (a *Audio) offset(sample int) time.Duration {
+	return time.Duration(sample) * time.Second / time.Duration(a.SampleRate)
+}
+
+// EncodeWAV writes the audio as mono 16-bit PCM WAV file.
+func (a *Audio) EncodeWAV(w io.Writer) error {
+	dataSize := int64(2 * len(a.Samples))
+	if dataSize > math.MaxUint32-36 {
+		return errors.New("audio is too long for a WAV file")
+	}
+
+	header := []any{
+		[4]byte{'R', 'I', 'F', 'F'}, uint32(36 + dataSize), [4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '}, uint32(16), uint16(1), uint16(1),
+		uint32(a.SampleRate), uint32(2 * a.SampleRate), uint16(2), uint16(16),
+		[4]byte{'d', 'a', 't', 'a'}, uint32(dataSize),
+	}
+
+	bw := bufio.NewWriter(w)
+
+	for _, v := range header {
+		if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, 2)
+
+	for _, s := range a.Samples {
+		if s > 1 {
+			s = 1
+		} else if s < -1 {
+			s = -1
+		}
+
+		binary.LittleEndian.PutUint16(buf, uint16(int16(s*math.MaxInt16)))
+
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WriteWAVFile writes the audio to the named file as mono 16-bit PCM WAV.
+func (a *Audio) WriteWAVFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := a.EncodeWAV(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ConcatenateAudio concatenates the clips into one clip, separated by the specified gap of silence.
+// All clips must have the same sample rate.
+func ConcatenateAudio(gap time.Duration, clips ...*Audio) (*Audio, error) {
+	if len(clips) == 0 {
+		return nil, errors.New("clips are required")
+	}
+
+	sampleRate := clips[0].SampleRate
+	gapSamples := int(gap.Seconds() * float64(sampleRate))
+
+	total := 0
+
+	for _, clip := range clips {
+		if clip.SampleRate != sampleRate {
+			return nil, fmt.Errorf("sample rate mismatch: %d != %d", clip.SampleRate, sampleRate)
+		}
+
+		total += len(clip.Samples) + gapSamples
+	}
+
+	samples := make([]float32, 0, total)
+
+	for i, clip := range clips {
+		if i > 0 {
+			samples = append(samples, make([]float32, gapSamples)...)
+		}
+
+		samples = append(samples, clip.Samples...)
+	}
+
+	return &Audio{SampleRate: sampleRate, Samples: samples}, nil
+}
+
+// WAV format codes
+const (
+	wavFormatPCM        = 1
+	wavFormatIEEEFloat  = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// DecodeWAV decodes a WAV file with integer (8, 16, 24 or 32 bit) or float (32 or 64 bit) samples.
+// Multiple channels are mixed down to mono.
+func DecodeWAV(r io.Reader) (*Audio, error) {
+	br := bufio.NewReader(r)
+
+	riff := make([]byte, 12)
+	if _, err := io.ReadFull(br, riff); err != nil {
+		return nil, fmt.Errorf("invalid WAV header: %w", err)
+	}
+
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, errors.New("invalid WAV header: missing RIFF/WAVE marker")
+	}
+
+	var (
+		format        uint16
+		channels      int
+		sampleRate    int
+		bitsPerSample int
+		hasFormat     bool
+	)
+
+	for {
+		chunkHeader := make([]byte, 8)
+		if _, err := io.ReadFull(br, chunkHeader); err != nil {
+			return nil, fmt.Errorf("invalid WAV file: missing data chunk: %w", err)
+		}
+
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, errors.New("invalid WAV file: fmt chunk is too small")
+			}
+
+			fmtChunk := make([]byte, chunkSize+chunkSize%2)
+			if _, err := io.ReadFull(br, fmtChunk); err != nil {
+				return nil, err
+			}
+
+			format = binary.LittleEndian.Uint16(fmtChunk[0:2])
+			channels = int(binary.LittleEndian.Uint16(fmtChunk[2:4]))
+			sampleRate = int(binary.LittleEndian.Uint32(fmtChunk[4:8]))
+			bitsPerSample = int(binary.LittleEndian.Uint16(fmtChunk[14:16]))
+
+			if format == wavFormatExtensible && chunkSize >= 26 {
+				// The first two bytes of the sub format GUID hold the actual format code
+				format = binary.LittleEndian.Uint16(fmtChunk[24:26])
+			}
+
+			hasFormat = true
+		case "data":
+			if !hasFormat {
+				return nil, errors.New("invalid WAV file: data chunk before fmt chunk")
+			}
+
+			// Streamed WAV files may report a wrong size, so the data is read up to the size or EOF
+			data, err := io.ReadAll(io.LimitReader(br, chunkSize))
+			if err != nil {
+				return nil, err
+			}
+
+			return decodePCMSamples(data, format, channels, sampleRate, bitsPerSample)
+		default:
+			if _, err := io.CopyN(io.Discard, br, chunkSize+chunkSize%2); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// DecodePCM decodes raw signed 16-bit little-endian PCM data with the specified sample rate
+// and number of interleaved channels. Multiple channels are mixed down to mono.
+func DecodePCM(r io.Reader, sampleRate, channels int) (*Audio, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodePCMSamples(data, wavFormatPCM, channels, sampleRate, 16)
+}
+
+// decodePCMSamples converts interleaved sample data into mono float samples.
+func decodePCMSamples(data []byte, format uint16, channels, sampleRate, bitsPerSample int) (*Audio, error) {
+	if channels <= 0 || sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid audio format: %d channels at %d Hz", channels, sampleRate)
+	}
+
+	var decode func(b []byte) float32
+
+	switch {
+	case format == wavFormatPCM && bitsPerSample == 8:
+		decode = func(b []byte) float32 { return (float32(b[0]) - 128) / 128 }
+	case format == wavFormatPCM && bitsPerSample == 16:
+		decode = func(b []byte) float32 { return float32(int16(binary.LittleEndian.Uint16(b))) / 32768 }
+	case format == wavFormatPCM && bitsPerSample == 24:
+		decode = func(b []byte) float32 {
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			return float32(v) / 8388608
+		}
+	case format == wavFormatPCM && bitsPerSample == 32:
+		decode = func(b []byte) float32 { return float32(int32(binary.LittleEndian.Uint32(b))) / 2147483648 }
+	case format == wavFormatIEEEFloat && bitsPerSample == 32:
+		decode = func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }
+	case format == wavFormatIEEEFloat && bitsPerSample == 64:
+		decode = func(b []byte) float32 { return float32(math.Float64frombits(binary.LittleEndian.Uint64(b))) }
+	default:
+		return nil, fmt.Errorf("unsupported audio format %d with %d bits per sample", format, bitsPerSample)
+	}
+
+	sampleSize := bitsPerSample / 8
+	frameSize := sampleSize * channels
+	samples := make([]float32, len(data)/frameSize)
+
+	for i := range samples {
+		frame := data[i*frameSize : (i+1)*frameSize]
+
+		var sum float32
+		for c := 0; c < channels; c++ {
+			sum += decode(frame[c*sampleSize : (c+1)*sampleSize])
+		}
+
+		samples[i] = sum / float32(channels)
+	}
+
+	return &Audio{SampleRate: sampleRate, Samples: samples}, nil
+}
+
+// rms returns the root mean square energy of the samples.
+func rms(samples []float32) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, s := range samples {
+		sum += float64(s) * float64(s)
+	}
+
+	return math.Sqrt(sum / float64(len(samples)))
+}
@@ -0,0 +1,64 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// Used with AudioClassificationRequest
+type AudioClassificationParameters struct {
+	// (Default: None). Integer. The number of top labels to return.
+	TopK *int `json:"top_k,omitempty"`
+
+	// (Default: None). The function applied to the model outputs to retrieve the scores,
+	// one of sigmoid, softmax or none.
+	FunctionToApply string `json:"function_to_apply,omitempty"`
+}
+
+// Request structure for the audio classification endpoint
+type AudioClassificationRequest struct {
+	// (Required) The audio to classify. Either Inputs or Filename must be set.
+	Inputs io.Reader
+
+	// The path of the audio file to classify, used if Inputs is nil.
+	Filename string
+
+	// The content type of the audio, e.g. audio/wav. Detected from the data if empty.
+	ContentType string
+
+	Parameters AudioClassificationParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the audio classification endpoint
+type AudioClassificationResponse []struct {
+	// The label for the class (model specific).
+	Label string `json:"label"`
+
+	// A float that represents how likely it is that the audio belongs to this class.
+	Score float64 `json:"score"`
+}
+
+// AudioClassification performs audio classification using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided audio.
+// The response contains the labels with their scores or an error if the request fails.
+func (ic *InferenceClient) AudioClassification(ctx context.Context, req *AudioClassificationRequest) (AudioClassificationResponse, error) {
+	data, contentType, err := readBinaryInputWithContentType(req.Inputs, req.Filename, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "audio-classification", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	audioClassificationResponse := AudioClassificationResponse{}
+	if err := json.Unmarshal(body, &audioClassificationResponse); err != nil {
+		return nil, err
+	}
+
+	return audioClassificationResponse, nil
+}
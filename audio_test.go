@@ -0,0 +1,153 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWAV(t *testing.T) {
+	audio := &Audio{SampleRate: 8000, Samples: []float32{0, 0.5, -0.5, 1, -1}}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, audio.EncodeWAV(buf))
+
+	decoded, err := DecodeWAV(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 8000, decoded.SampleRate)
+	assert.Len(t, decoded.Samples, 5)
+
+	for i, s := range audio.Samples {
+		assert.InDelta(t, s, decoded.Samples[i], 0.001)
+	}
+
+	_, err = DecodeWAV(strings.NewReader("not a wav file"))
+	assert.Error(t, err)
+}
+
+func TestDecodePCM(t *testing.T) {
+	// Two stereo frames are mixed down to two mono samples
+	audio, err := DecodePCM(bytes.NewReader([]byte{0x00, 0x40, 0x00, 0x00, 0x00, 0xC0, 0x00, 0xC0}), 16000, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0.25, -0.5}, audio.Samples)
+}
+
+func TestTranscribeLongAudio(t *testing.T) {
+	const sampleRate = 1000
+
+	// Encodes the position of each sample in its value, so the server knows where a chunk starts
+	audio := &Audio{SampleRate: sampleRate, Samples: make([]float32, 10*sampleRate)}
+	for i := range audio.Samples {
+		audio.Samples[i] = float32(i) / float32(len(audio.Samples))
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := struct {
+			Inputs []byte `json:"inputs"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		chunk, err := DecodeWAV(bytes.NewReader(payload.Inputs))
+		assert.NoError(t, err)
+
+		start := int(math.Round(float64(chunk.Samples[0]) * float64(len(audio.Samples)) / sampleRate))
+		seconds := len(chunk.Samples) / sampleRate
+
+		// Returns one word per second, named after its position in the whole audio
+		chunks := []string{}
+		for i := 0; i < seconds; i++ {
+			chunks = append(chunks, fmt.Sprintf(`{"text":" w%d","timestamp":[%d,%d]}`, start+i, i, i+1))
+		}
+
+		_, _ = fmt.Fprintf(w, `{"text":"","chunks":[%s]}`, strings.Join(chunks, ","))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.TranscribeLongAudio(context.Background(), &LongAudioTranscriptionRequest{
+		Audio:       audio,
+		ChunkLength: 4 * time.Second,
+		Overlap:     time.Second,
+		Model:       "openai/whisper-tiny",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "w0 w1 w2 w3 w4 w5 w6 w7 w8 w9", res.Text)
+	assert.Equal(t, TranscriptionSegment{Start: 3 * time.Second, End: 4 * time.Second, Text: "w3"}, res.Segments[3])
+	assert.True(t, strings.HasPrefix(res.SRT(), "1\n00:00:00,000 --> 00:00:01,000\nw0\n\n2\n"))
+	assert.True(t, strings.HasPrefix(res.WebVTT(), "WEBVTT\n\n00:00:00.000 --> 00:00:01.000\nw0\n\n"))
+}
+
+func TestTranscribeLongAudioCanceled(t *testing.T) {
+	client := NewInferenceClient("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.TranscribeLongAudio(ctx, &LongAudioTranscriptionRequest{
+		Audio:          &Audio{SampleRate: 1000, Samples: make([]float32, 10000)},
+		ChunkLength:    4 * time.Second,
+		Overlap:        time.Second,
+		MaxConcurrency: 1,
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPlanAudioChunks(t *testing.T) {
+	// A loud signal with a short silence at 3.5s
+	audio := &Audio{SampleRate: 1000, Samples: make([]float32, 10000)}
+	for i := range audio.Samples {
+		if i < 3500 || i >= 3600 {
+			audio.Samples[i] = 0.5
+		}
+	}
+
+	chunks, err := planAudioChunks(audio, 4*time.Second, time.Second, false, 0.01)
+	assert.NoError(t, err)
+	assert.Equal(t, []audioChunk{{0, 4000}, {3000, 7000}, {6000, 10000}}, chunks)
+
+	// The first chunk is cut at the silence and the next chunk starts there without overlap
+	chunks, err = planAudioChunks(audio, 4*time.Second, time.Second, true, 0.01)
+	assert.NoError(t, err)
+	assert.Equal(t, []audioChunk{{0, 3510}, {3510, 7510}, {6510, 10000}}, chunks)
+
+	_, err = planAudioChunks(&Audio{SampleRate: 8000, Samples: make([]float32, 10)}, 100*time.Microsecond, 0, false, 0.01)
+	assert.EqualError(t, err, "chunk length must be at least one sample")
+
+	_, err = planAudioChunks(audio, 4*time.Second, -time.Second, false, 0.01)
+	assert.EqualError(t, err, "overlap must not be negative")
+
+	client := NewInferenceClient("")
+
+	_, err = client.TranscribeLongAudio(context.Background(), &LongAudioTranscriptionRequest{Audio: audio, Overlap: -time.Second})
+	assert.EqualError(t, err, "overlap must not be negative")
+}
+
+func TestRemoveRepeatedWords(t *testing.T) {
+	assert.Equal(t, "over the lazy dog", removeRepeatedWords("the quick brown fox jumps", "Fox jumps over the lazy dog"))
+	assert.Equal(t, "the end", removeRepeatedWords("this is the", "the end"))
+	assert.Equal(t, "", removeRepeatedWords("this is the end.", "end"))
+}
+
+func TestConcatenateAudio(t *testing.T) {
+	a := &Audio{SampleRate: 10, Samples: []float32{1, 1}}
+	b := &Audio{SampleRate: 10, Samples: []float32{-1}}
+
+	clip, err := ConcatenateAudio(200*time.Millisecond, a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 1, 0, 0, -1}, clip.Samples)
+
+	_, err = ConcatenateAudio(0, a, &Audio{SampleRate: 20})
+	assert.EqualError(t, err, "sample rate mismatch: 20 != 10")
+}
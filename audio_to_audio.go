@@ -0,0 +1,58 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// Request structure for the audio-to-audio endpoint
+type AudioToAudioRequest struct {
+	// (Required) The audio to process. Either Inputs or Filename must be set.
+	Inputs io.Reader
+
+	// The path of the audio file to process, used if Inputs is nil.
+	Filename string
+
+	// The content type of the audio, e.g. audio/wav. Detected from the data if empty.
+	ContentType string
+
+	Options Options
+	Model   string
+}
+
+// Response structure for the audio-to-audio endpoint. Source separation models return
+// one output per source, enhancement models a single output.
+type AudioToAudioResponse []struct {
+	// The label of the output, e.g. the name of the separated source.
+	Label string `json:"label"`
+
+	// The content type of the audio, e.g. audio/flac.
+	ContentType string `json:"content-type"`
+
+	// The audio data, decoded from base64.
+	Blob []byte `json:"blob"`
+}
+
+// AudioToAudio performs audio-to-audio tasks like source separation or speech enhancement
+// using the specified model. It sends a POST request to the Hugging Face inference endpoint
+// with the provided audio. The response contains the resulting audio blobs or an error if
+// the request fails.
+func (ic *InferenceClient) AudioToAudio(ctx context.Context, req *AudioToAudioRequest) (AudioToAudioResponse, error) {
+	data, contentType, err := readBinaryInputWithContentType(req.Inputs, req.Filename, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "audio-to-audio", data, contentType, nil, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	audioToAudioResponse := AudioToAudioResponse{}
+	if err := json.Unmarshal(body, &audioToAudioResponse); err != nil {
+		return nil, err
+	}
+
+	return audioToAudioResponse, nil
+}
@@ -0,0 +1,124 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// ReturnTimestamps specifies the granularity of the timestamps returned by speech recognition models.
+type ReturnTimestamps string
+
+const (
+	// ReturnTimestampsSegment returns timestamps for segments of the transcription.
+	ReturnTimestampsSegment ReturnTimestamps = "segment"
+
+	// ReturnTimestampsWord returns timestamps for each word of the transcription.
+	ReturnTimestampsWord ReturnTimestamps = "word"
+
+	// ReturnTimestampsChar returns timestamps for each character of the transcription (CTC models only).
+	ReturnTimestampsChar ReturnTimestamps = "char"
+)
+
+// MarshalJSON encodes segment level timestamps as true and other granularities as string.
+func (rt ReturnTimestamps) MarshalJSON() ([]byte, error) {
+	if rt == ReturnTimestampsSegment {
+		return json.Marshal(true)
+	}
+
+	return json.Marshal(string(rt))
+}
+
+// Used with AutomaticSpeechRecognitionParameters
+type AutomaticSpeechRecognitionGenerateKwargs struct {
+	// The language of the audio, e.g. "english" (Whisper models only).
+	Language string `json:"language,omitempty"`
+
+	// The task to perform, either "transcribe" or "translate" (Whisper models only).
+	Task string `json:"task,omitempty"`
+
+	// (Default: None). Integer. The maximum number of tokens to generate.
+	MaxNewTokens *int `json:"max_new_tokens,omitempty"`
+
+	// (Default: None). Integer. The number of beams for beam search.
+	NumBeams *int `json:"num_beams,omitempty"`
+
+	// (Default: None). Bool. Whether to use sampling instead of greedy decoding.
+	DoSample *bool `json:"do_sample,omitempty"`
+
+	// (Default: 1.0). Float. The temperature of the sampling operation.
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+// Used with AutomaticSpeechRecognitionRequest
+type AutomaticSpeechRecognitionParameters struct {
+	// Whether to return timestamps and at which granularity.
+	ReturnTimestamps ReturnTimestamps `json:"return_timestamps,omitempty"`
+
+	// (Default: None). Float. The length in seconds of the chunks the audio is split into by the
+	// pipeline. Enables the transcription of audio longer than the receptive field of the model.
+	ChunkLengthS *float64 `json:"chunk_length_s,omitempty"`
+
+	// (Default: ChunkLengthS / 6). Float. The length in seconds of the overlap on each side of a chunk.
+	StrideLengthS *float64 `json:"stride_length_s,omitempty"`
+
+	// Parameters passed to the generate method of the model.
+	GenerateKwargs *AutomaticSpeechRecognitionGenerateKwargs `json:"generate_kwargs,omitempty"`
+}
+
+// Request structure for the automatic speech recognition endpoint
+type AutomaticSpeechRecognitionRequest struct {
+	// (Required) The audio to transcribe. Either Inputs or Filename must be set.
+	Inputs io.Reader
+
+	// The path of the audio file to transcribe, used if Inputs is nil.
+	Filename string
+
+	// The content type of the audio, e.g. audio/wav. Detected from the data if empty.
+	ContentType string
+
+	Parameters AutomaticSpeechRecognitionParameters
+	Options    Options
+	Model      string
+}
+
+// Used with AutomaticSpeechRecognitionResponse
+type AutomaticSpeechRecognitionChunk struct {
+	// The text of the chunk.
+	Text string `json:"text"`
+
+	// The start and end of the chunk in seconds. The end is zero if the model
+	// did not predict it, which may happen for the last chunk.
+	Timestamp [2]float64 `json:"timestamp"`
+}
+
+// Response structure for the automatic speech recognition endpoint
+type AutomaticSpeechRecognitionResponse struct {
+	// The transcription of the audio.
+	Text string `json:"text"`
+
+	// The chunks of the transcription with their timestamps, if requested with ReturnTimestamps.
+	Chunks []AutomaticSpeechRecognitionChunk `json:"chunks,omitempty"`
+}
+
+// AutomaticSpeechRecognition performs automatic speech recognition using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided audio.
+// The response contains the transcription or an error if the request fails.
+func (ic *InferenceClient) AutomaticSpeechRecognition(ctx context.Context, req *AutomaticSpeechRecognitionRequest) (*AutomaticSpeechRecognitionResponse, error) {
+	data, contentType, err := readBinaryInputWithContentType(req.Inputs, req.Filename, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "automatic-speech-recognition", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	automaticSpeechRecognitionResponse := AutomaticSpeechRecognitionResponse{}
+	if err := json.Unmarshal(body, &automaticSpeechRecognitionResponse); err != nil {
+		return nil, err
+	}
+
+	return &automaticSpeechRecognitionResponse, nil
+}
@@ -0,0 +1,247 @@
+package huggingface
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RepoType specifies the type of a repository of the Hub.
+type RepoType string
+
+const (
+	RepoTypeModel   RepoType = "model"
+	RepoTypeDataset RepoType = "dataset"
+	RepoTypeSpace   RepoType = "space"
+)
+
+// ErrNotCached is returned if a file is requested in offline mode but is not in the local cache.
+var ErrNotCached = errors.New("file is not in the local cache")
+
+// commitHashRegexp matches full git commit hashes.
+var commitHashRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// etagRegexp matches the etags of blobs, which are git blob ids or sha256 checksums of LFS files.
+var etagRegexp = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// DefaultCacheDir returns the directory of the Hub cache shared with the huggingface_hub Python library.
+// It is HF_HUB_CACHE if set, otherwise HF_HOME/hub, otherwise XDG_CACHE_HOME/huggingface/hub and
+// finally ~/.cache/huggingface/hub.
+func DefaultCacheDir() string {
+	if dir := os.Getenv("HF_HUB_CACHE"); dir != "" {
+		return dir
+	}
+
+	if dir := os.Getenv("HF_HOME"); dir != "" {
+		return filepath.Join(dir, "hub")
+	}
+
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "huggingface", "hub")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "huggingface", "hub")
+	}
+
+	return filepath.Join(home, ".cache", "huggingface", "hub")
+}
+
+// isOfflineEnv reports whether HF_HUB_OFFLINE enables the offline mode.
+func isOfflineEnv() bool {
+	switch strings.ToLower(os.Getenv("HF_HUB_OFFLINE")) {
+	case "1", "on", "yes", "true":
+		return true
+	default:
+		return false
+	}
+}
+
+// repoCache represents the directory of a repository within the Hub cache:
+//
+//	<cache>/models--org--name/blobs/<etag>
+//	<cache>/models--org--name/snapshots/<commit>/<filename> -> ../../blobs/<etag>
+//	<cache>/models--org--name/refs/<branch> (contains the commit hash)
+//	<cache>/.locks/models--org--name/<etag>.lock
+type repoCache struct {
+	cacheDir string
+	repoType RepoType
+	repoID   string
+	folder   string
+}
+
+// newRepoCache returns the cache of the repository within the cache directory.
+func newRepoCache(cacheDir string, repoType RepoType, repoID string) (*repoCache, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	if repoType == "" {
+		repoType = RepoTypeModel
+	}
+
+	switch repoType {
+	case RepoTypeModel, RepoTypeDataset, RepoTypeSpace:
+	default:
+		return nil, fmt.Errorf("unknown repo type %q", repoType)
+	}
+
+	parts := append([]string{string(repoType) + "s"}, strings.Split(repoID, "/")...)
+
+	return &repoCache{
+		cacheDir: cacheDir,
+		repoType: repoType,
+		repoID:   repoID,
+		folder:   strings.Join(parts, "--"),
+	}, nil
+}
+
+// checkRevision returns an error if the revision can not be stored as a ref within the cache.
+func checkRevision(revision string) error {
+	if !filepath.IsLocal(filepath.FromSlash(revision)) || strings.Contains(revision, "..") {
+		return fmt.Errorf("invalid revision %q", revision)
+	}
+
+	return nil
+}
+
+// dir returns the directory of the repository.
+func (rc *repoCache) dir() string {
+	return filepath.Join(rc.cacheDir, rc.folder)
+}
+
+// blobPath returns the path of the blob with the etag.
+func (rc *repoCache) blobPath(etag string) string {
+	return filepath.Join(rc.dir(), "blobs", etag)
+}
+
+// snapshotPath returns the path of the file within the snapshot of the commit.
+func (rc *repoCache) snapshotPath(commit, filename string) string {
+	return filepath.Join(rc.dir(), "snapshots", commit, filepath.FromSlash(filename))
+}
+
+// refPath returns the path of the file storing the commit of the ref.
+func (rc *repoCache) refPath(ref string) string {
+	return filepath.Join(rc.dir(), "refs", filepath.FromSlash(ref))
+}
+
+// lockPath returns the path of the lock file of the blob with the etag.
+func (rc *repoCache) lockPath(etag string) string {
+	return filepath.Join(rc.cacheDir, ".locks", rc.folder, etag+".lock")
+}
+
+// resolveCommit returns the commit of the revision, which is either a commit hash or a ref
+// stored in the cache.
+func (rc *repoCache) resolveCommit(revision string) (string, error) {
+	if commitHashRegexp.MatchString(revision) {
+		return revision, nil
+	}
+
+	data, err := os.ReadFile(rc.refPath(revision))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotCached
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeRef stores the commit of the ref.
+func (rc *repoCache) writeRef(ref, commit string) error {
+	if ref == commit {
+		return nil
+	}
+
+	path := rc.refPath(ref)
+
+	if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) == commit {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(commit), 0o644)
+}
+
+// cachedFile returns the path of the file in the snapshot of the revision if it is cached.
+func (rc *repoCache) cachedFile(revision, filename string) (string, error) {
+	commit, err := rc.resolveCommit(revision)
+	if err != nil {
+		return "", err
+	}
+
+	path := rc.snapshotPath(commit, filename)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotCached
+		}
+
+		return "", err
+	}
+
+	return path, nil
+}
+
+// cachedSnapshot returns the directory of the snapshot of the revision if it is cached.
+func (rc *repoCache) cachedSnapshot(revision string) (string, error) {
+	commit, err := rc.resolveCommit(revision)
+	if err != nil {
+		return "", err
+	}
+
+	dir := rc.snapshotPath(commit, "")
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotCached
+		}
+
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// linkSnapshot links the file within the snapshot of the commit to the blob with the etag.
+// The blob is copied if the file system does not support symlinks.
+func (rc *repoCache) linkSnapshot(commit, filename, etag string) (string, error) {
+	path := rc.snapshotPath(commit, filename)
+	blob := rc.blobPath(etag)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	target, err := filepath.Rel(filepath.Dir(path), blob)
+	if err != nil {
+		return "", err
+	}
+
+	_ = os.Remove(path)
+
+	if err := os.Symlink(target, path); err != nil {
+		if err := copyFile(blob, path); err != nil {
+			return "", err
+		}
+	}
+
+	return path, nil
+}
+
+// copyFile copies the file at src to dst.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, data, 0o644)
+}
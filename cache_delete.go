@@ -0,0 +1,221 @@
+package huggingface
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DeleteCacheStrategy describes the paths deleted from the Hub cache to remove revisions.
+// It is created by DeleteRevisions or RetentionStrategy and applied with Execute.
+type DeleteCacheStrategy struct {
+	// The directory of the cache.
+	Dir string
+
+	// The number of bytes freed by the deletion.
+	ExpectedFreedSize int64
+
+	// The blobs that are no longer referenced.
+	Blobs []string
+
+	// The refs pointing to deleted revisions.
+	Refs []string
+
+	// The repositories of which all revisions are deleted.
+	Repos []string
+
+	// The snapshots of the deleted revisions.
+	Snapshots []string
+}
+
+// RetentionPolicy specifies which revisions are kept in the Hub cache.
+type RetentionPolicy struct {
+	// The number of most recently modified revisions kept for each repository. Zero keeps all revisions.
+	KeepLast int
+
+	// The maximum size of the cache in bytes. The least recently modified revisions are deleted until the
+	// cache fits. Zero does not limit the size.
+	MaxSize int64
+}
+
+// DeleteRevisions returns the strategy to delete the revisions with the specified commit hashes.
+// Blobs are only deleted if they are not referenced by other revisions, and repositories without
+// remaining revisions are deleted entirely.
+func (ci *HubCacheInfo) DeleteRevisions(commits ...string) (*DeleteCacheStrategy, error) {
+	selected := map[string]bool{}
+
+	for _, commit := range commits {
+		found := false
+
+		for _, repo := range ci.Repos {
+			for _, revision := range repo.Revisions {
+				if revision.Commit == commit {
+					selected[revision.Path] = true
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("revision %s is not in the cache", commit)
+		}
+	}
+
+	return ci.deleteStrategy(selected), nil
+}
+
+// RetentionStrategy returns the strategy to delete the revisions not kept by the retention policy.
+func (ci *HubCacheInfo) RetentionStrategy(policy RetentionPolicy) *DeleteCacheStrategy {
+	selected := map[string]bool{}
+
+	if policy.KeepLast > 0 {
+		for _, repo := range ci.Repos {
+			// Revisions are sorted by descending modification time
+			for i := policy.KeepLast; i < len(repo.Revisions); i++ {
+				selected[repo.Revisions[i].Path] = true
+			}
+		}
+	}
+
+	strategy := ci.deleteStrategy(selected)
+
+	if policy.MaxSize > 0 {
+		remaining := []CachedRevisionInfo{}
+
+		for _, repo := range ci.Repos {
+			for _, revision := range repo.Revisions {
+				if !selected[revision.Path] {
+					remaining = append(remaining, revision)
+				}
+			}
+		}
+
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return remaining[i].LastModified.Before(remaining[j].LastModified)
+		})
+
+		for _, revision := range remaining {
+			if ci.Size-strategy.ExpectedFreedSize <= policy.MaxSize {
+				break
+			}
+
+			selected[revision.Path] = true
+			strategy = ci.deleteStrategy(selected)
+		}
+	}
+
+	return strategy
+}
+
+// deleteStrategy returns the strategy to delete the revisions with the selected snapshot paths.
+func (ci *HubCacheInfo) deleteStrategy(selected map[string]bool) *DeleteCacheStrategy {
+	strategy := &DeleteCacheStrategy{
+		Dir:       ci.Dir,
+		Blobs:     []string{},
+		Refs:      []string{},
+		Repos:     []string{},
+		Snapshots: []string{},
+	}
+
+	for _, repo := range ci.Repos {
+		deleted, kept := []CachedRevisionInfo{}, map[string]bool{}
+
+		for _, revision := range repo.Revisions {
+			if selected[revision.Path] {
+				deleted = append(deleted, revision)
+				continue
+			}
+
+			for _, file := range revision.Files {
+				kept[file.BlobPath] = true
+			}
+		}
+
+		if len(deleted) == 0 {
+			continue
+		}
+
+		if len(deleted) == len(repo.Revisions) {
+			strategy.Repos = append(strategy.Repos, repo.Path)
+			strategy.ExpectedFreedSize += repo.Size
+
+			continue
+		}
+
+		for _, revision := range deleted {
+			strategy.Snapshots = append(strategy.Snapshots, revision.Path)
+
+			for _, ref := range revision.Refs {
+				strategy.Refs = append(strategy.Refs, filepath.Join(repo.Path, "refs", filepath.FromSlash(ref)))
+			}
+
+			for _, file := range revision.Files {
+				if kept[file.BlobPath] {
+					continue
+				}
+
+				kept[file.BlobPath] = true // Avoids deleting blobs shared by deleted revisions twice
+
+				strategy.Blobs = append(strategy.Blobs, file.BlobPath)
+				strategy.ExpectedFreedSize += file.Size
+			}
+		}
+	}
+
+	return strategy
+}
+
+// Execute deletes the paths of the strategy. Paths outside of the cache directory are refused.
+// Paths that are already deleted are ignored.
+func (s *DeleteCacheStrategy) Execute() error {
+	errs := []error{}
+
+	remove := func(path string, all bool) {
+		if !isWithinDir(s.Dir, path) {
+			errs = append(errs, fmt.Errorf("refusing to delete %s outside of the cache %s", path, s.Dir))
+			return
+		}
+
+		var err error
+		if all {
+			err = os.RemoveAll(path)
+		} else {
+			err = os.Remove(path)
+		}
+
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, path := range s.Snapshots {
+		remove(path, true)
+	}
+
+	for _, path := range s.Refs {
+		remove(path, false)
+	}
+
+	for _, path := range s.Blobs {
+		remove(path, false)
+	}
+
+	for _, path := range s.Repos {
+		remove(path, true)
+	}
+
+	return errors.Join(errs...)
+}
+
+// isWithinDir reports whether the path is located below the directory.
+func isWithinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
@@ -0,0 +1,389 @@
+package huggingface
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CachedFileInfo represents a file of a cached revision.
+type CachedFileInfo struct {
+	// The path of the file relative to the root of the repository.
+	Name string
+
+	// The path of the file within the snapshot.
+	Path string
+
+	// The path of the blob the file links to.
+	BlobPath string
+
+	// The size of the blob in bytes.
+	Size int64
+
+	// The time the blob was last accessed.
+	BlobLastAccessed time.Time
+
+	// The time the blob was last modified.
+	BlobLastModified time.Time
+}
+
+// CachedRevisionInfo represents a cached revision of a repository.
+type CachedRevisionInfo struct {
+	// The commit hash of the revision.
+	Commit string
+
+	// The directory of the snapshot.
+	Path string
+
+	// The refs pointing to the revision, e.g. main.
+	Refs []string
+
+	// The files of the revision.
+	Files []CachedFileInfo
+
+	// The size of the blobs of the revision in bytes. Blobs shared with other revisions are included.
+	Size int64
+
+	// The time the snapshot was last modified, i.e. the time a file was last added.
+	LastModified time.Time
+}
+
+// CachedRepoInfo represents a cached repository.
+type CachedRepoInfo struct {
+	// The id of the repository.
+	RepoID string
+
+	// The type of the repository.
+	RepoType RepoType
+
+	// The directory of the repository.
+	Path string
+
+	// The size of all blobs of the repository in bytes. Blobs shared by revisions are counted once.
+	Size int64
+
+	// The number of blobs of the repository.
+	NumFiles int
+
+	// The cached revisions, the most recently modified first.
+	Revisions []CachedRevisionInfo
+
+	// Blobs that are not referenced by any revision.
+	UnreferencedBlobs []string
+
+	// Partial files of interrupted downloads.
+	IncompleteFiles []string
+
+	// The time a blob of the repository was last accessed.
+	LastAccessed time.Time
+
+	// The time a blob of the repository was last modified.
+	LastModified time.Time
+}
+
+// HubCacheInfo represents the content of the Hub cache.
+type HubCacheInfo struct {
+	// The directory of the cache.
+	Dir string
+
+	// The size of all blobs of the cache in bytes.
+	Size int64
+
+	// The cached repositories, sorted by type and id.
+	Repos []CachedRepoInfo
+
+	// Problems found in the cache, such as corrupted repositories or dangling symlinks.
+	// Corrupted repositories are not part of Repos.
+	Warnings []error
+}
+
+// ScanCacheDir scans the Hub cache at the specified directory, or DefaultCacheDir() if empty.
+// Entries that can not be interpreted are reported as warnings instead of failing the scan.
+func ScanCacheDir(dir string) (*HubCacheInfo, error) {
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &HubCacheInfo{
+		Dir:   dir,
+		Repos: []CachedRepoInfo{},
+	}
+
+	for _, entry := range entries {
+		// Skips the locks and other files of the cache
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		repo, warnings, err := scanCachedRepo(filepath.Join(dir, entry.Name()))
+
+		info.Warnings = append(info.Warnings, warnings...)
+
+		if err != nil {
+			info.Warnings = append(info.Warnings, err)
+			continue
+		}
+
+		info.Repos = append(info.Repos, *repo)
+		info.Size += repo.Size
+	}
+
+	sort.Slice(info.Repos, func(i, j int) bool {
+		if info.Repos[i].RepoType != info.Repos[j].RepoType {
+			return info.Repos[i].RepoType < info.Repos[j].RepoType
+		}
+
+		return info.Repos[i].RepoID < info.Repos[j].RepoID
+	})
+
+	return info, nil
+}
+
+// parseRepoFolder returns the type and id of the repository of the folder, e.g. models--org--name.
+func parseRepoFolder(name string) (RepoType, string, error) {
+	parts := strings.Split(name, "--")
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("invalid repository folder %q", name)
+	}
+
+	repoType := RepoType(strings.TrimSuffix(parts[0], "s"))
+
+	switch repoType {
+	case RepoTypeModel, RepoTypeDataset, RepoTypeSpace:
+	default:
+		return "", "", fmt.Errorf("invalid repository type of folder %q", name)
+	}
+
+	return repoType, strings.Join(parts[1:], "/"), nil
+}
+
+// scanCachedRepo scans the directory of a cached repository. Problems that do not prevent the scan
+// are returned as warnings.
+func scanCachedRepo(dir string) (*CachedRepoInfo, []error, error) {
+	repoType, repoID, err := parseRepoFolder(filepath.Base(dir))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snapshots, err := os.ReadDir(filepath.Join(dir, "snapshots"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("corrupted repository %s: %w", repoID, err)
+	}
+
+	repo := &CachedRepoInfo{
+		RepoID:    repoID,
+		RepoType:  repoType,
+		Path:      dir,
+		Revisions: []CachedRevisionInfo{},
+	}
+
+	warnings := []error{}
+	blobs := map[string]CachedFileInfo{}
+
+	for _, snapshot := range snapshots {
+		if !snapshot.IsDir() {
+			warnings = append(warnings, fmt.Errorf("repository %s: unexpected file %s in snapshots", repoID, snapshot.Name()))
+			continue
+		}
+
+		revision := CachedRevisionInfo{
+			Commit: snapshot.Name(),
+			Path:   filepath.Join(dir, "snapshots", snapshot.Name()),
+			Refs:   []string{},
+			Files:  []CachedFileInfo{},
+		}
+
+		if stat, err := os.Stat(revision.Path); err == nil {
+			revision.LastModified = stat.ModTime()
+		}
+
+		revisionBlobs := map[string]bool{}
+
+		err := filepath.WalkDir(revision.Path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			file, err := scanCachedFile(revision.Path, path)
+			if err != nil {
+				warnings = append(warnings, fmt.Errorf("repository %s: %w", repoID, err))
+				return nil
+			}
+
+			revision.Files = append(revision.Files, *file)
+			blobs[file.BlobPath] = *file
+
+			if !revisionBlobs[file.BlobPath] {
+				revisionBlobs[file.BlobPath] = true
+				revision.Size += file.Size
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("corrupted repository %s: %w", repoID, err)
+		}
+
+		repo.Revisions = append(repo.Revisions, revision)
+	}
+
+	refs, err := scanCachedRefs(filepath.Join(dir, "refs"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("corrupted repository %s: %w", repoID, err)
+	}
+
+	for ref, commit := range refs {
+		found := false
+
+		for i := range repo.Revisions {
+			if repo.Revisions[i].Commit == commit {
+				repo.Revisions[i].Refs = append(repo.Revisions[i].Refs, ref)
+				found = true
+			}
+		}
+
+		if !found {
+			warnings = append(warnings, fmt.Errorf("repository %s: ref %s points to missing revision %s", repoID, ref, commit))
+		}
+	}
+
+	for i := range repo.Revisions {
+		sort.Strings(repo.Revisions[i].Refs)
+	}
+
+	sort.Slice(repo.Revisions, func(i, j int) bool {
+		return repo.Revisions[i].LastModified.After(repo.Revisions[j].LastModified)
+	})
+
+	for _, blob := range blobs {
+		repo.Size += blob.Size
+
+		if blob.BlobLastAccessed.After(repo.LastAccessed) {
+			repo.LastAccessed = blob.BlobLastAccessed
+		}
+
+		if blob.BlobLastModified.After(repo.LastModified) {
+			repo.LastModified = blob.BlobLastModified
+		}
+	}
+
+	repo.NumFiles = len(blobs)
+
+	blobEntries, err := os.ReadDir(filepath.Join(dir, "blobs"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("corrupted repository %s: %w", repoID, err)
+	}
+
+	for _, entry := range blobEntries {
+		path := filepath.Join(dir, "blobs", entry.Name())
+
+		switch {
+		case strings.Contains(entry.Name(), ".incomplete"):
+			repo.IncompleteFiles = append(repo.IncompleteFiles, path)
+		case !hasBlob(blobs, path):
+			repo.UnreferencedBlobs = append(repo.UnreferencedBlobs, path)
+		}
+	}
+
+	return repo, warnings, nil
+}
+
+// hasBlob reports whether the blob at path is referenced. Paths are compared after resolving
+// symlinks of the cache directory.
+func hasBlob(blobs map[string]CachedFileInfo, path string) bool {
+	if _, ok := blobs[path]; ok {
+		return true
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+
+	_, ok := blobs[resolved]
+
+	return ok
+}
+
+// scanCachedFile returns the information of the file of a snapshot. Files copied into the snapshot
+// instead of linked are their own blob.
+func scanCachedFile(snapshotDir, path string) (*CachedFileInfo, error) {
+	name, err := filepath.Rel(snapshotDir, path)
+	if err != nil {
+		return nil, err
+	}
+
+	blobPath := path
+
+	if lstat, err := os.Lstat(path); err == nil && lstat.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+
+		blobPath = filepath.Clean(target)
+	}
+
+	stat, err := os.Stat(blobPath)
+	if err != nil {
+		return nil, fmt.Errorf("dangling file %s: %w", path, err)
+	}
+
+	return &CachedFileInfo{
+		Name:             filepath.ToSlash(name),
+		Path:             path,
+		BlobPath:         blobPath,
+		Size:             stat.Size(),
+		BlobLastAccessed: fileAccessTime(stat),
+		BlobLastModified: stat.ModTime(),
+	}, nil
+}
+
+// scanCachedRefs returns the commits of the refs stored in the directory.
+func scanCachedRefs(dir string) (map[string]string, error) {
+	refs := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && path == dir {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		refs[filepath.ToSlash(name)] = strings.TrimSpace(string(data))
+
+		return nil
+	})
+
+	return refs, err
+}
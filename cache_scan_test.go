@@ -0,0 +1,159 @@
+package huggingface
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCommitOld = "1111111111111111111111111111111111111111"
+	testCommitNew = "2222222222222222222222222222222222222222"
+)
+
+// writeCachedRevision writes a revision with the files into the cache. The blobs are named after the content.
+func writeCachedRevision(t *testing.T, rc *repoCache, commit, ref string, modified time.Time, files map[string]string) {
+	for name, content := range files {
+		blob := rc.blobPath(sha256Hex(content))
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(blob), 0o755))
+		assert.NoError(t, os.WriteFile(blob, []byte(content), 0o600))
+		assert.NoError(t, os.Chtimes(blob, modified, modified))
+
+		_, err := rc.linkSnapshot(commit, name, sha256Hex(content))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, os.Chtimes(rc.snapshotPath(commit, ""), modified, modified))
+
+	if ref != "" {
+		assert.NoError(t, rc.writeRef(ref, commit))
+	}
+}
+
+// newTestCache creates a cache with two revisions of org/model sharing the config and a dataset.
+func newTestCache(t *testing.T) string {
+	dir := t.TempDir()
+
+	model, err := newRepoCache(dir, RepoTypeModel, "org/model")
+	assert.NoError(t, err)
+
+	writeCachedRevision(t, model, testCommitOld, "", time.Now().Add(-2*time.Hour), map[string]string{
+		"config.json":       "config",
+		"model.safetensors": "old weights",
+	})
+
+	writeCachedRevision(t, model, testCommitNew, "main", time.Now().Add(-time.Hour), map[string]string{
+		"config.json":       "config",
+		"model.safetensors": "new weights!",
+	})
+
+	dataset, err := newRepoCache(dir, RepoTypeDataset, "org/data")
+	assert.NoError(t, err)
+
+	writeCachedRevision(t, dataset, testCommit, "main", time.Now(), map[string]string{
+		"data/train.parquet": "rows",
+	})
+
+	return dir
+}
+
+func TestScanCacheDir(t *testing.T) {
+	dir := newTestCache(t)
+
+	// A dangling symlink, a partial download and an unexpected folder
+	model, _ := newRepoCache(dir, RepoTypeModel, "org/model")
+	assert.NoError(t, os.Symlink("../../blobs/missing", model.snapshotPath(testCommitNew, "missing.json")))
+	assert.NoError(t, os.WriteFile(model.blobPath("abc.incomplete"), []byte("partial"), 0o600))
+	assert.NoError(t, os.WriteFile(model.blobPath("orphan"), []byte("orphan"), 0o600))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "invalid"), 0o755))
+
+	info, err := ScanCacheDir(dir)
+	assert.NoError(t, err)
+
+	assert.Len(t, info.Repos, 2)
+	assert.Equal(t, "org/data", info.Repos[0].RepoID)
+	assert.Equal(t, RepoTypeDataset, info.Repos[0].RepoType)
+
+	repo := info.Repos[1]
+	assert.Equal(t, "org/model", repo.RepoID)
+	assert.Equal(t, 3, repo.NumFiles)
+	assert.Equal(t, int64(len("config")+len("old weights")+len("new weights!")), repo.Size)
+	assert.Equal(t, int64(len("config")+len("rows")+len("old weights")+len("new weights!")), info.Size)
+
+	assert.Len(t, repo.Revisions, 2)
+	assert.Equal(t, testCommitNew, repo.Revisions[0].Commit)
+	assert.Equal(t, []string{"main"}, repo.Revisions[0].Refs)
+	assert.Equal(t, int64(len("config")+len("new weights!")), repo.Revisions[0].Size)
+
+	assert.Equal(t, []string{model.blobPath("orphan")}, repo.UnreferencedBlobs)
+	assert.Equal(t, []string{model.blobPath("abc.incomplete")}, repo.IncompleteFiles)
+
+	assert.Len(t, info.Warnings, 2)
+
+	messages := []string{info.Warnings[0].Error(), info.Warnings[1].Error()}
+	assert.Contains(t, strings.Join(messages, "\n"), "dangling file")
+	assert.Contains(t, strings.Join(messages, "\n"), `invalid repository folder "invalid"`)
+}
+
+func TestDeleteRevisions(t *testing.T) {
+	dir := newTestCache(t)
+
+	info, err := ScanCacheDir(dir)
+	assert.NoError(t, err)
+
+	t.Run("Unknown revision", func(t *testing.T) {
+		_, err := info.DeleteRevisions("unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("Shared blobs are kept", func(t *testing.T) {
+		strategy, err := info.DeleteRevisions(testCommitNew)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len("new weights!")), strategy.ExpectedFreedSize)
+		assert.Len(t, strategy.Blobs, 1)
+		assert.Len(t, strategy.Refs, 1)
+		assert.Empty(t, strategy.Repos)
+
+		assert.NoError(t, strategy.Execute())
+
+		after, err := ScanCacheDir(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, info.Size-strategy.ExpectedFreedSize, after.Size)
+		assert.Len(t, after.Repos[1].Revisions, 1)
+		assert.Empty(t, after.Warnings)
+	})
+
+	t.Run("Outside of the cache", func(t *testing.T) {
+		strategy := &DeleteCacheStrategy{Dir: dir, Blobs: []string{filepath.Join(dir, "..", "file")}}
+		assert.Error(t, strategy.Execute())
+	})
+}
+
+func TestRetentionStrategy(t *testing.T) {
+	dir := newTestCache(t)
+
+	info, err := ScanCacheDir(dir)
+	assert.NoError(t, err)
+
+	t.Run("Keep last", func(t *testing.T) {
+		strategy := info.RetentionStrategy(RetentionPolicy{KeepLast: 1})
+		assert.Len(t, strategy.Snapshots, 1)
+		assert.Equal(t, int64(len("old weights")), strategy.ExpectedFreedSize)
+	})
+
+	t.Run("Max size", func(t *testing.T) {
+		// Deleting the oldest revision is not enough, the whole model is deleted
+		strategy := info.RetentionStrategy(RetentionPolicy{MaxSize: int64(len("rows") + len("config"))})
+		assert.Equal(t, []string{filepath.Join(dir, "models--org--model")}, strategy.Repos)
+		assert.Equal(t, info.Repos[1].Size, strategy.ExpectedFreedSize)
+
+		assert.NoError(t, strategy.Execute())
+		assert.NoDirExists(t, filepath.Join(dir, "models--org--model"))
+		assert.DirExists(t, filepath.Join(dir, "datasets--org--data"))
+	})
+}
@@ -0,0 +1,225 @@
+// Command hf inspects and cleans up the local Hugging Face Hub cache.
+//
+// Usage:
+//
+//	hf scan-cache [-dir DIR] [-v]
+//	hf delete-cache [-dir DIR] [-keep-last N] [-max-size SIZE] [-dry-run] [COMMIT...]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hupe1980/go-huggingface"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "scan-cache":
+		err = scanCache(os.Args[2:])
+	case "delete-cache":
+		err = deleteCache(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `Usage: hf <command> [flags]
+
+Commands:
+  scan-cache     Lists the repositories and revisions of the Hub cache
+  delete-cache   Deletes revisions from the Hub cache
+
+Run "hf <command> -h" for the flags of a command.
+`)
+}
+
+func scanCache(args []string) error {
+	fs := flag.NewFlagSet("scan-cache", flag.ExitOnError)
+	dir := fs.String("dir", "", "the cache directory (default: HF_HUB_CACHE or ~/.cache/huggingface/hub)")
+	verbose := fs.Bool("v", false, "list the revisions of each repository")
+
+	_ = fs.Parse(args)
+
+	info, err := huggingface.ScanCacheDir(*dir)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	if *verbose {
+		fmt.Fprintln(w, "REPO ID\tREPO TYPE\tREVISION\tSIZE ON DISK\tNB FILES\tLAST_MODIFIED\tREFS")
+
+		for _, repo := range info.Repos {
+			for _, revision := range repo.Revisions {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n", repo.RepoID, repo.RepoType, revision.Commit, formatSize(revision.Size),
+					len(revision.Files), formatAge(revision.LastModified), strings.Join(revision.Refs, ", "))
+			}
+		}
+	} else {
+		fmt.Fprintln(w, "REPO ID\tREPO TYPE\tSIZE ON DISK\tNB FILES\tLAST_ACCESSED\tLAST_MODIFIED\tREFS")
+
+		for _, repo := range info.Repos {
+			refs := []string{}
+			for _, revision := range repo.Revisions {
+				refs = append(refs, revision.Refs...)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n", repo.RepoID, repo.RepoType, formatSize(repo.Size), repo.NumFiles,
+				formatAge(repo.LastAccessed), formatAge(repo.LastModified), strings.Join(refs, ", "))
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nDone in the cache %s: %d repo(s) for a total of %s.\n", info.Dir, len(info.Repos), formatSize(info.Size))
+
+	for _, warning := range info.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+
+	return nil
+}
+
+func deleteCache(args []string) error {
+	fs := flag.NewFlagSet("delete-cache", flag.ExitOnError)
+	dir := fs.String("dir", "", "the cache directory (default: HF_HUB_CACHE or ~/.cache/huggingface/hub)")
+	keepLast := fs.Int("keep-last", 0, "the number of most recent revisions kept for each repository")
+	maxSize := fs.String("max-size", "", "the maximum size of the cache, e.g. 20GB")
+	dryRun := fs.Bool("dry-run", false, "only print what would be deleted")
+
+	_ = fs.Parse(args)
+
+	info, err := huggingface.ScanCacheDir(*dir)
+	if err != nil {
+		return err
+	}
+
+	var strategy *huggingface.DeleteCacheStrategy
+
+	switch {
+	case fs.NArg() > 0:
+		strategy, err = info.DeleteRevisions(fs.Args()...)
+		if err != nil {
+			return err
+		}
+	case *keepLast > 0 || *maxSize != "":
+		policy := huggingface.RetentionPolicy{KeepLast: *keepLast}
+
+		if *maxSize != "" {
+			policy.MaxSize, err = parseSize(*maxSize)
+			if err != nil {
+				return err
+			}
+		}
+
+		strategy = info.RetentionStrategy(policy)
+	default:
+		return errors.New("either commits, -keep-last or -max-size are required")
+	}
+
+	for _, paths := range [][]string{strategy.Repos, strategy.Snapshots, strategy.Refs, strategy.Blobs} {
+		for _, path := range paths {
+			fmt.Println("delete", path)
+		}
+	}
+
+	if *dryRun {
+		fmt.Printf("Would free %s.\n", formatSize(strategy.ExpectedFreedSize))
+		return nil
+	}
+
+	if err := strategy.Execute(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Freed %s.\n", formatSize(strategy.ExpectedFreedSize))
+
+	return nil
+}
+
+// formatSize formats the size in bytes with a decimal unit, e.g. 1.2G.
+func formatSize(size int64) string {
+	const units = "KMGTPE"
+
+	if size < 1000 {
+		return fmt.Sprintf("%d", size)
+	}
+
+	value, unit := float64(size), -1
+	for value >= 1000 && unit < len(units)-1 {
+		value /= 1000
+		unit++
+	}
+
+	return fmt.Sprintf("%.1f%c", value, units[unit])
+}
+
+// parseSize parses a size with an optional decimal unit, e.g. 500M, 20GB or 1.5T.
+func parseSize(s string) (int64, error) {
+	multipliers := map[string]float64{"": 1, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12}
+
+	number := strings.TrimRight(strings.ToUpper(strings.TrimSpace(s)), "BKMGT")
+	unit := strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(s))[len(number):], "B")
+
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	return int64(value * multiplier), nil
+}
+
+// formatAge formats the time relative to now, e.g. 3 days ago.
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	d := time.Since(t)
+
+	switch {
+	case d < time.Minute:
+		return "a few seconds ago"
+	case d < time.Hour:
+		return fmt.Sprintf("%d minutes ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d hours ago", int(d.Hours()))
+	case d < 60*24*time.Hour:
+		return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%d months ago", int(d.Hours()/24/30))
+	}
+}
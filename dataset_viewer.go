@@ -0,0 +1,556 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// maxDatasetRowsPageLength is the maximum number of rows the dataset viewer returns per request.
+const maxDatasetRowsPageLength = 100
+
+// DatasetViewerClientOptions represents options for the DatasetViewerClient.
+type DatasetViewerClientOptions struct {
+	// (Default: https://datasets-server.huggingface.co). The endpoint of the dataset viewer API.
+	Endpoint string
+
+	HTTPClient HTTPClient
+}
+
+// DatasetViewerClient is a client for the dataset viewer API, which provides the splits, rows,
+// parquet files and statistics of the datasets on the Hub.
+type DatasetViewerClient struct {
+	serverClient
+}
+
+// NewDatasetViewerClient creates a new DatasetViewerClient instance with the specified token.
+// The token is optional for public datasets.
+func NewDatasetViewerClient(token string, optFns ...func(o *DatasetViewerClientOptions)) *DatasetViewerClient {
+	opts := DatasetViewerClientOptions{
+		Endpoint: "https://datasets-server.huggingface.co",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &DatasetViewerClient{
+		serverClient: newServerClient(opts.Endpoint, token, opts.HTTPClient),
+	}
+}
+
+// DatasetValidity reports which features of the dataset viewer are available for a dataset.
+type DatasetValidity struct {
+	// Whether the first rows of the dataset are available.
+	Preview bool `json:"preview"`
+
+	// Whether all rows of the dataset are available.
+	Viewer bool `json:"viewer"`
+
+	// Whether the dataset can be searched.
+	Search bool `json:"search"`
+
+	// Whether the dataset can be filtered.
+	Filter bool `json:"filter"`
+
+	// Whether the statistics of the dataset are available.
+	Statistics bool `json:"statistics"`
+}
+
+// IsValid returns which features of the dataset viewer are available for the dataset.
+func (dc *DatasetViewerClient) IsValid(ctx context.Context, dataset string) (*DatasetValidity, error) {
+	if dataset == "" {
+		return nil, errors.New("dataset is required")
+	}
+
+	datasetValidity := DatasetValidity{}
+	if err := dc.getJSON(ctx, "/is-valid", url.Values{"dataset": {dataset}}, &datasetValidity); err != nil {
+		return nil, err
+	}
+
+	return &datasetValidity, nil
+}
+
+// DatasetSplit identifies a split of a configuration of a dataset.
+type DatasetSplit struct {
+	Dataset string `json:"dataset"`
+	Config  string `json:"config"`
+	Split   string `json:"split"`
+}
+
+// Splits returns the splits of all configurations of the dataset.
+func (dc *DatasetViewerClient) Splits(ctx context.Context, dataset string) ([]DatasetSplit, error) {
+	if dataset == "" {
+		return nil, errors.New("dataset is required")
+	}
+
+	res := struct {
+		Splits []DatasetSplit `json:"splits"`
+	}{}
+
+	if err := dc.getJSON(ctx, "/splits", url.Values{"dataset": {dataset}}, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Splits, nil
+}
+
+// DatasetFeature describes a column of a dataset.
+type DatasetFeature struct {
+	// The index of the column.
+	FeatureIdx int `json:"feature_idx"`
+
+	// The name of the column.
+	Name string `json:"name"`
+
+	// The type of the column in the format of the datasets library, e.g. {"dtype":"string","_type":"Value"}.
+	Type map[string]any `json:"type"`
+}
+
+// DatasetRow represents a row of a dataset.
+type DatasetRow struct {
+	// The index of the row within the split.
+	RowIdx int64 `json:"row_idx"`
+
+	// The cells of the row by column name.
+	Row map[string]any `json:"row"`
+
+	// The names of the columns whose cells were truncated.
+	TruncatedCells []string `json:"truncated_cells"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes the row and keeps the encoded cells for Decode.
+func (r *DatasetRow) UnmarshalJSON(data []byte) error {
+	type datasetRow DatasetRow
+
+	row := struct {
+		datasetRow
+		Raw json.RawMessage `json:"row"`
+	}{}
+
+	if err := json.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	*r = DatasetRow(row.datasetRow)
+	r.raw = row.Raw
+
+	if err := json.Unmarshal(row.Raw, &r.Row); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Decode decodes the cells of the row into v, typically a pointer to a struct with json tags
+// matching the column names.
+func (r DatasetRow) Decode(v any) error {
+	if r.raw != nil {
+		return json.Unmarshal(r.raw, v)
+	}
+
+	data, err := json.Marshal(r.Row)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// Used with FirstRows
+type FirstRowsRequest struct {
+	// (Required) The id of the dataset, e.g. stanfordnlp/imdb.
+	Dataset string
+
+	// (Required) The configuration of the dataset.
+	Config string
+
+	// (Required) The split of the configuration.
+	Split string
+}
+
+// Response structure of FirstRows
+type FirstRowsResponse struct {
+	Features []DatasetFeature `json:"features"`
+	Rows     []DatasetRow     `json:"rows"`
+
+	// Whether the rows were truncated to fit the size limit of the response.
+	Truncated bool `json:"truncated"`
+}
+
+// FirstRows returns the features and the first rows of the split, which are available
+// for most datasets even if the viewer is not.
+func (dc *DatasetViewerClient) FirstRows(ctx context.Context, req *FirstRowsRequest) (*FirstRowsResponse, error) {
+	query, err := splitQuery(req.Dataset, req.Config, req.Split)
+	if err != nil {
+		return nil, err
+	}
+
+	firstRowsResponse := FirstRowsResponse{}
+	if err := dc.getJSON(ctx, "/first-rows", query, &firstRowsResponse); err != nil {
+		return nil, err
+	}
+
+	return &firstRowsResponse, nil
+}
+
+// Used with Rows
+type DatasetRowsRequest struct {
+	// (Required) The id of the dataset, e.g. stanfordnlp/imdb.
+	Dataset string
+
+	// (Required) The configuration of the dataset.
+	Config string
+
+	// (Required) The split of the configuration.
+	Split string
+
+	// (Default: 0). The index of the first row.
+	Offset int64
+
+	// (Default: 100). The number of rows fetched per request. The maximum is 100.
+	PageLength int
+
+	// (Default: 0). The maximum number of rows. Zero iterates until the end of the split.
+	Limit int
+}
+
+// Rows iterates over the rows of the split, starting at the offset of the request.
+// The rows are fetched page by page while iterating.
+func (dc *DatasetViewerClient) Rows(ctx context.Context, req *DatasetRowsRequest) *Iterator[DatasetRow] {
+	query, err := splitQuery(req.Dataset, req.Config, req.Split)
+	if err != nil {
+		return errIterator[DatasetRow](err)
+	}
+
+	return dc.rowsIterator(ctx, "/rows", query, req.Offset, req.PageLength, req.Limit)
+}
+
+// Used with Search
+type SearchRowsRequest struct {
+	// (Required) The id of the dataset, e.g. stanfordnlp/imdb.
+	Dataset string
+
+	// (Required) The configuration of the dataset.
+	Config string
+
+	// (Required) The split of the configuration.
+	Split string
+
+	// (Required) The text to search for in the string columns.
+	Query string
+
+	// (Default: 0). The index of the first matching row.
+	Offset int64
+
+	// (Default: 100). The number of rows fetched per request. The maximum is 100.
+	PageLength int
+
+	// (Default: 0). The maximum number of rows. Zero iterates over all matching rows.
+	Limit int
+}
+
+// Search iterates over the rows of the split that contain the query text.
+// The rows are fetched page by page while iterating.
+func (dc *DatasetViewerClient) Search(ctx context.Context, req *SearchRowsRequest) *Iterator[DatasetRow] {
+	query, err := splitQuery(req.Dataset, req.Config, req.Split)
+	if err != nil {
+		return errIterator[DatasetRow](err)
+	}
+
+	if req.Query == "" {
+		return errIterator[DatasetRow](errors.New("query is required"))
+	}
+
+	query.Set("query", req.Query)
+
+	return dc.rowsIterator(ctx, "/search", query, req.Offset, req.PageLength, req.Limit)
+}
+
+// Used with Filter
+type FilterRowsRequest struct {
+	// (Required) The id of the dataset, e.g. stanfordnlp/imdb.
+	Dataset string
+
+	// (Required) The configuration of the dataset.
+	Config string
+
+	// (Required) The split of the configuration.
+	Split string
+
+	// The SQL condition the rows must match, e.g. "label"=1 AND "text" LIKE '%great%'.
+	Where string
+
+	// The SQL order of the rows, e.g. "label" DESC.
+	OrderBy string
+
+	// (Default: 0). The index of the first matching row.
+	Offset int64
+
+	// (Default: 100). The number of rows fetched per request. The maximum is 100.
+	PageLength int
+
+	// (Default: 0). The maximum number of rows. Zero iterates over all matching rows.
+	Limit int
+}
+
+// Filter iterates over the rows of the split that match the where condition, in the specified order.
+// The rows are fetched page by page while iterating.
+func (dc *DatasetViewerClient) Filter(ctx context.Context, req *FilterRowsRequest) *Iterator[DatasetRow] {
+	query, err := splitQuery(req.Dataset, req.Config, req.Split)
+	if err != nil {
+		return errIterator[DatasetRow](err)
+	}
+
+	if req.Where == "" && req.OrderBy == "" {
+		return errIterator[DatasetRow](errors.New("where or orderBy is required"))
+	}
+
+	if req.Where != "" {
+		query.Set("where", req.Where)
+	}
+
+	if req.OrderBy != "" {
+		query.Set("orderby", req.OrderBy)
+	}
+
+	return dc.rowsIterator(ctx, "/filter", query, req.Offset, req.PageLength, req.Limit)
+}
+
+// datasetRowsPage is a page of rows returned by the rows, search and filter endpoints.
+type datasetRowsPage struct {
+	Rows         []DatasetRow `json:"rows"`
+	NumRowsTotal int64        `json:"num_rows_total"`
+}
+
+// rowsIterator creates an iterator over the rows returned by the endpoint at path, which are
+// paginated with the offset and length query parameters.
+func (dc *DatasetViewerClient) rowsIterator(ctx context.Context, path string, query url.Values, offset int64, pageLength, limit int) *Iterator[DatasetRow] {
+	if pageLength <= 0 || pageLength > maxDatasetRowsPageLength {
+		pageLength = maxDatasetRowsPageLength
+	}
+
+	if limit > 0 && limit < pageLength {
+		pageLength = limit
+	}
+
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	query.Set("length", strconv.Itoa(pageLength))
+
+	u := fmt.Sprintf("%s%s?%s", dc.endpoint, path, query.Encode())
+
+	return newIterator(ctx, u, limit, func(ctx context.Context, pageURL string) ([]DatasetRow, string, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, "", err
+		}
+
+		httpReq.Header.Set("Accept", "application/json")
+
+		body, err := dc.do(httpReq)
+		if err != nil {
+			return nil, "", err
+		}
+
+		page := datasetRowsPage{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, "", err
+		}
+
+		parsed, err := url.Parse(pageURL)
+		if err != nil {
+			return nil, "", err
+		}
+
+		q := parsed.Query()
+
+		pageOffset, err := strconv.ParseInt(q.Get("offset"), 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+
+		next := pageOffset + int64(len(page.Rows))
+		if len(page.Rows) == 0 || next >= page.NumRowsTotal {
+			return page.Rows, "", nil
+		}
+
+		q.Set("offset", strconv.FormatInt(next, 10))
+		parsed.RawQuery = q.Encode()
+
+		return page.Rows, parsed.String(), nil
+	})
+}
+
+// ParquetFile represents a parquet file of a split converted by the dataset viewer.
+type ParquetFile struct {
+	Dataset string `json:"dataset"`
+	Config  string `json:"config"`
+	Split   string `json:"split"`
+
+	// The URL of the parquet file.
+	URL string `json:"url"`
+
+	// The name of the parquet file.
+	Filename string `json:"filename"`
+
+	// The size of the parquet file in bytes.
+	Size int64 `json:"size"`
+}
+
+// Parquet returns the parquet files of the dataset. The files are restricted to a configuration or
+// split if config or split are not empty.
+func (dc *DatasetViewerClient) Parquet(ctx context.Context, dataset, config, split string) ([]ParquetFile, error) {
+	if dataset == "" {
+		return nil, errors.New("dataset is required")
+	}
+
+	query := url.Values{"dataset": {dataset}}
+
+	if config != "" {
+		query.Set("config", config)
+	}
+
+	if split != "" {
+		query.Set("split", split)
+	}
+
+	res := struct {
+		ParquetFiles []ParquetFile `json:"parquet_files"`
+	}{}
+
+	if err := dc.getJSON(ctx, "/parquet", query, &res); err != nil {
+		return nil, err
+	}
+
+	return res.ParquetFiles, nil
+}
+
+// DatasetSize contains the size of a dataset, configuration or split.
+type DatasetSize struct {
+	Dataset string `json:"dataset"`
+
+	// The configuration, empty for the size of the dataset.
+	Config string `json:"config,omitempty"`
+
+	// The split, empty for the size of the dataset or a configuration.
+	Split string `json:"split,omitempty"`
+
+	// The size of the original files in bytes, unknown for splits.
+	NumBytesOriginalFiles int64 `json:"num_bytes_original_files,omitempty"`
+
+	// The size of the parquet files in bytes.
+	NumBytesParquetFiles int64 `json:"num_bytes_parquet_files"`
+
+	// The size of the data loaded in memory in bytes.
+	NumBytesMemory int64 `json:"num_bytes_memory"`
+
+	// The number of rows.
+	NumRows int64 `json:"num_rows"`
+
+	// The number of columns, unknown for the dataset.
+	NumColumns int `json:"num_columns,omitempty"`
+}
+
+// Response structure of Size
+type DatasetSizeResponse struct {
+	Dataset DatasetSize   `json:"dataset"`
+	Configs []DatasetSize `json:"configs"`
+	Splits  []DatasetSize `json:"splits"`
+}
+
+// Size returns the size of the dataset and of each of its configurations and splits.
+func (dc *DatasetViewerClient) Size(ctx context.Context, dataset string) (*DatasetSizeResponse, error) {
+	if dataset == "" {
+		return nil, errors.New("dataset is required")
+	}
+
+	res := struct {
+		Size DatasetSizeResponse `json:"size"`
+	}{}
+
+	if err := dc.getJSON(ctx, "/size", url.Values{"dataset": {dataset}}, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Size, nil
+}
+
+// ColumnStatistics contains the statistics of a column of a split.
+type ColumnStatistics struct {
+	// The name of the column.
+	ColumnName string `json:"column_name"`
+
+	// The type of the column, e.g. int, float, string_label, string_text, class_label or bool.
+	ColumnType string `json:"column_type"`
+
+	// The statistics of the column, which depend on its type, e.g. min, max, mean, median, std,
+	// nan_count, nan_proportion, n_unique, frequencies or histogram.
+	ColumnStatistics map[string]any `json:"column_statistics"`
+}
+
+// Response structure of Statistics
+type DatasetStatisticsResponse struct {
+	// The number of rows of the split.
+	NumExamples int64 `json:"num_examples"`
+
+	Statistics []ColumnStatistics `json:"statistics"`
+
+	// Whether the statistics were computed on a part of the split only.
+	Partial bool `json:"partial"`
+}
+
+// Statistics returns the statistics of the columns of the split.
+func (dc *DatasetViewerClient) Statistics(ctx context.Context, dataset, config, split string) (*DatasetStatisticsResponse, error) {
+	query, err := splitQuery(dataset, config, split)
+	if err != nil {
+		return nil, err
+	}
+
+	datasetStatisticsResponse := DatasetStatisticsResponse{}
+	if err := dc.getJSON(ctx, "/statistics", query, &datasetStatisticsResponse); err != nil {
+		return nil, err
+	}
+
+	return &datasetStatisticsResponse, nil
+}
+
+// getJSON sends a GET request with the query to the specified path and decodes the JSON response into v.
+func (dc *DatasetViewerClient) getJSON(ctx context.Context, path string, query url.Values, v any) error {
+	body, err := dc.get(ctx, fmt.Sprintf("%s?%s", path, query.Encode()))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// splitQuery returns the query parameters identifying a split or an error if one is missing.
+func splitQuery(dataset, config, split string) (url.Values, error) {
+	if dataset == "" {
+		return nil, errors.New("dataset is required")
+	}
+
+	if config == "" {
+		return nil, errors.New("config is required")
+	}
+
+	if split == "" {
+		return nil, errors.New("split is required")
+	}
+
+	return url.Values{
+		"dataset": {dataset},
+		"config":  {config},
+		"split":   {split},
+	}, nil
+}
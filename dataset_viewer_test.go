@@ -0,0 +1,173 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatasetViewerRows(t *testing.T) {
+	const total = 5
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		assert.Equal(t, "/rows", r.URL.Path)
+		assert.Equal(t, "org/data", r.URL.Query().Get("dataset"))
+		assert.Equal(t, "default", r.URL.Query().Get("config"))
+		assert.Equal(t, "train", r.URL.Query().Get("split"))
+		assert.Equal(t, "Bearer your-token", r.Header.Get("Authorization"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+
+		rows := ""
+		for i := offset; i < offset+length && i < total; i++ {
+			if rows != "" {
+				rows += ","
+			}
+
+			rows += fmt.Sprintf(`{"row_idx":%d,"row":{"text":"row %d","label":%d},"truncated_cells":[]}`, i, i, i%2)
+		}
+
+		fmt.Fprintf(w, `{"features":[],"rows":[%s],"num_rows_total":%d,"num_rows_per_page":100,"partial":false}`, rows, total)
+	}))
+	defer server.Close()
+
+	client := NewDatasetViewerClient("your-token", func(o *DatasetViewerClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	t.Run("All pages", func(t *testing.T) {
+		requests = 0
+
+		rows, err := client.Rows(context.Background(), &DatasetRowsRequest{
+			Dataset:    "org/data",
+			Config:     "default",
+			Split:      "train",
+			Offset:     1,
+			PageLength: 2,
+		}).All()
+		assert.NoError(t, err)
+		assert.Len(t, rows, 4)
+		assert.Equal(t, 2, requests)
+		assert.Equal(t, int64(1), rows[0].RowIdx)
+		assert.Equal(t, "row 4", rows[3].Row["text"])
+
+		row := struct {
+			Text  string `json:"text"`
+			Label int    `json:"label"`
+		}{}
+
+		assert.NoError(t, rows[0].Decode(&row))
+		assert.Equal(t, "row 1", row.Text)
+		assert.Equal(t, 1, row.Label)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		rows, err := client.Rows(context.Background(), &DatasetRowsRequest{
+			Dataset: "org/data",
+			Config:  "default",
+			Split:   "train",
+			Limit:   3,
+		}).All()
+		assert.NoError(t, err)
+		assert.Len(t, rows, 3)
+	})
+
+	t.Run("Missing split", func(t *testing.T) {
+		_, err := client.Rows(context.Background(), &DatasetRowsRequest{
+			Dataset: "org/data",
+			Config:  "default",
+		}).All()
+		assert.EqualError(t, err, "split is required")
+	})
+}
+
+func TestDatasetViewerFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/filter", r.URL.Path)
+		assert.Equal(t, `"label"=1`, r.URL.Query().Get("where"))
+		assert.Equal(t, `"text" DESC`, r.URL.Query().Get("orderby"))
+
+		_, _ = w.Write([]byte(`{"rows":[{"row_idx":3,"row":{"text":"b"}},{"row_idx":1,"row":{"text":"a"}}],"num_rows_total":2}`))
+	}))
+	defer server.Close()
+
+	client := NewDatasetViewerClient("", func(o *DatasetViewerClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	rows, err := client.Filter(context.Background(), &FilterRowsRequest{
+		Dataset: "org/data",
+		Config:  "default",
+		Split:   "train",
+		Where:   `"label"=1`,
+		OrderBy: `"text" DESC`,
+	}).All()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, int64(3), rows[0].RowIdx)
+}
+
+func TestDatasetViewerMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "org/data", r.URL.Query().Get("dataset"))
+
+		switch r.URL.Path {
+		case "/is-valid":
+			_, _ = w.Write([]byte(`{"preview":true,"viewer":true,"search":false,"filter":true,"statistics":true}`))
+		case "/splits":
+			_, _ = w.Write([]byte(`{"splits":[{"dataset":"org/data","config":"default","split":"train"},{"dataset":"org/data","config":"default","split":"test"}],"pending":[],"failed":[]}`))
+		case "/parquet":
+			assert.Equal(t, "default", r.URL.Query().Get("config"))
+			_, _ = w.Write([]byte(`{"parquet_files":[{"dataset":"org/data","config":"default","split":"train","url":"https://huggingface.co/0000.parquet","filename":"0000.parquet","size":42}]}`))
+		case "/size":
+			_, _ = w.Write([]byte(`{"size":{"dataset":{"dataset":"org/data","num_bytes_original_files":100,"num_bytes_parquet_files":42,"num_bytes_memory":200,"num_rows":5},"configs":[],"splits":[{"dataset":"org/data","config":"default","split":"train","num_bytes_parquet_files":42,"num_bytes_memory":200,"num_rows":5,"num_columns":2}]}}`))
+		case "/statistics":
+			_, _ = w.Write([]byte(`{"num_examples":5,"statistics":[{"column_name":"label","column_type":"int","column_statistics":{"min":0,"max":1}}],"partial":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewDatasetViewerClient("", func(o *DatasetViewerClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	validity, err := client.IsValid(context.Background(), "org/data")
+	assert.NoError(t, err)
+	assert.True(t, validity.Viewer)
+	assert.False(t, validity.Search)
+
+	splits, err := client.Splits(context.Background(), "org/data")
+	assert.NoError(t, err)
+	assert.Equal(t, []DatasetSplit{{"org/data", "default", "train"}, {"org/data", "default", "test"}}, splits)
+
+	files, err := client.Parquet(context.Background(), "org/data", "default", "")
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, int64(42), files[0].Size)
+
+	size, err := client.Size(context.Background(), "org/data")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), size.Dataset.NumRows)
+	assert.Equal(t, 2, size.Splits[0].NumColumns)
+
+	statistics, err := client.Statistics(context.Background(), "org/data", "default", "train")
+	assert.NoError(t, err)
+	assert.Equal(t, "label", statistics.Statistics[0].ColumnName)
+	assert.Equal(t, float64(1), statistics.Statistics[0].ColumnStatistics["max"])
+
+	_, err = client.FirstRows(context.Background(), &FirstRowsRequest{Dataset: "org/data", Config: "x", Split: "y"})
+	assert.Error(t, err)
+}
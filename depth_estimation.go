@@ -0,0 +1,127 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Request structure for the depth estimation endpoint
+type DepthEstimationRequest struct {
+	// (Required) The image to estimate the depth of.
+	Inputs  ImageInput
+	Options Options
+	Model   string
+}
+
+// Response structure for the depth estimation endpoint
+type DepthEstimationResponse struct {
+	// The depth map as grayscale image, where brighter pixels are closer to the camera.
+	Depth *image.Gray
+
+	// The raw depth predicted by the model, indexed by row and column.
+	PredictedDepth [][]float32
+}
+
+// UnmarshalJSON decodes the predicted depth and the base64 encoded depth image. If the response
+// does not contain an image, the depth image is created from the normalized predicted depth.
+func (r *DepthEstimationResponse) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		PredictedDepth json.RawMessage `json:"predicted_depth"`
+		Depth          string          `json:"depth"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if len(raw.PredictedDepth) > 0 {
+		// The predicted depth may include a batch dimension of size one
+		if err := json.Unmarshal(raw.PredictedDepth, &r.PredictedDepth); err != nil {
+			batch := [][][]float32{}
+			if err := json.Unmarshal(raw.PredictedDepth, &batch); err != nil || len(batch) != 1 {
+				return errors.New("unexpected predicted depth shape")
+			}
+
+			r.PredictedDepth = batch[0]
+		}
+	}
+
+	if raw.Depth == "" {
+		r.Depth = depthToGray(r.PredictedDepth)
+
+		return nil
+	}
+
+	depthData, err := base64.StdEncoding.DecodeString(raw.Depth)
+	if err != nil {
+		return err
+	}
+
+	depth, err := decodeGray(depthData)
+	if err != nil {
+		return err
+	}
+
+	r.Depth = depth
+
+	return nil
+}
+
+// DepthEstimation estimates the depth of an image using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided image.
+// The response contains the depth map or an error if the request fails.
+func (ic *InferenceClient) DepthEstimation(ctx context.Context, req *DepthEstimationRequest) (*DepthEstimationResponse, error) {
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "depth-estimation", data, contentType, nil, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	depthEstimationResponse := DepthEstimationResponse{}
+	if err := json.Unmarshal(body, &depthEstimationResponse); err != nil {
+		return nil, err
+	}
+
+	return &depthEstimationResponse, nil
+}
+
+// depthToGray converts the depth values to a grayscale image, scaled to the range of the values.
+func depthToGray(depth [][]float32) *image.Gray {
+	if len(depth) == 0 {
+		return image.NewGray(image.Rectangle{})
+	}
+
+	minDepth, maxDepth := float32(math.Inf(1)), float32(math.Inf(-1))
+
+	for _, row := range depth {
+		for _, v := range row {
+			if v < minDepth {
+				minDepth = v
+			}
+
+			if v > maxDepth {
+				maxDepth = v
+			}
+		}
+	}
+
+	gray := image.NewGray(image.Rect(0, 0, len(depth[0]), len(depth)))
+
+	for y, row := range depth {
+		for x, v := range row {
+			if maxDepth > minDepth {
+				gray.SetGray(x, y, color.Gray{Y: uint8((v - minDepth) / (maxDepth - minDepth) * 255)})
+			}
+		}
+	}
+
+	return gray
+}
@@ -0,0 +1,117 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// WordBox represents a word of a document and its bounding box, as recognized by OCR.
+type WordBox struct {
+	// The word.
+	Word string
+
+	// The bounding box of the word as [x0, y0, x1, y1], normalized to the range 0-1000.
+	Box [4]int
+}
+
+// MarshalJSON encodes the word box as [word, [x0, y0, x1, y1]].
+func (wb WordBox) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{wb.Word, wb.Box})
+}
+
+// Used with DocumentQuestionAnsweringRequest
+type DocumentQuestionAnsweringInputs struct {
+	// (Required) The image of the document.
+	Image ImageInput
+
+	// (Required) The question about the document.
+	Question string
+}
+
+// Used with DocumentQuestionAnsweringRequest
+type DocumentQuestionAnsweringParameters struct {
+	// (Default: 1). Integer. The number of answers to return.
+	TopK *int `json:"top_k,omitempty"`
+
+	// (Default: 128). Integer. The overlap in tokens between chunks if the document is too long to fit
+	// with the question in the model.
+	DocStride *int `json:"doc_stride,omitempty"`
+
+	// (Default: 15). Integer. The maximum length of predicted answers.
+	MaxAnswerLen *int `json:"max_answer_len,omitempty"`
+
+	// (Default: 384). Integer. The maximum length in tokens of the question and document chunks.
+	MaxSeqLen *int `json:"max_seq_len,omitempty"`
+
+	// (Default: 64). Integer. The maximum length in tokens of the question.
+	MaxQuestionLen *int `json:"max_question_len,omitempty"`
+
+	// (Default: false). Bool. Whether to accept impossible as an answer.
+	HandleImpossibleAnswer *bool `json:"handle_impossible_answer,omitempty"`
+
+	// (Default: None). The language of the document for the OCR, e.g. eng.
+	Lang string `json:"lang,omitempty"`
+
+	// (Default: None). The words and boxes of the document. If set, the model uses them instead of
+	// running OCR on the image.
+	WordBoxes []WordBox `json:"word_boxes,omitempty"`
+}
+
+// Request structure for the document question answering endpoint
+type DocumentQuestionAnsweringRequest struct {
+	// (Required)
+	Inputs     DocumentQuestionAnsweringInputs
+	Parameters DocumentQuestionAnsweringParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the document question answering endpoint
+type DocumentQuestionAnsweringResponse []struct {
+	// The answer to the question.
+	Answer string `json:"answer"`
+
+	// A float that represents how likely that the answer is correct.
+	Score float64 `json:"score"`
+
+	// The index of the first word of the answer within the words of the document.
+	Start int `json:"start"`
+
+	// The index of the last word of the answer within the words of the document.
+	End int `json:"end"`
+}
+
+// DocumentQuestionAnswering answers a question about a document image using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the base64 encoded image and the question.
+// The response contains the answers or an error if the request fails.
+func (ic *InferenceClient) DocumentQuestionAnswering(ctx context.Context, req *DocumentQuestionAnsweringRequest) (DocumentQuestionAnsweringResponse, error) {
+	if req.Inputs.Question == "" {
+		return nil, errors.New("question is required")
+	}
+
+	data, _, err := ic.readImageInput(ctx, req.Inputs.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.post(ctx, req.Model, "document-question-answering", map[string]any{
+		"inputs": map[string]string{
+			"image":    base64.StdEncoding.EncodeToString(data),
+			"question": req.Inputs.Question,
+		},
+		"parameters": req.Parameters,
+		"options":    req.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	documentQuestionAnsweringResponse := DocumentQuestionAnsweringResponse{}
+	if err := json.Unmarshal(body, &documentQuestionAnsweringResponse); err != nil {
+		return nil, err
+	}
+
+	return documentQuestionAnsweringResponse, nil
+}
@@ -0,0 +1,136 @@
+package huggingface
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+)
+
+// labelPalette contains the colors used to distinguish labels in annotated images.
+var labelPalette = []color.RGBA{
+	{230, 25, 75, 255},
+	{60, 180, 75, 255},
+	{0, 130, 200, 255},
+	{245, 130, 48, 255},
+	{145, 30, 180, 255},
+	{70, 240, 240, 255},
+	{240, 50, 230, 255},
+	{210, 245, 60, 255},
+	{0, 128, 128, 255},
+	{170, 110, 40, 255},
+}
+
+// labelColor returns the color of the label, which is the same for every call with the same label.
+func labelColor(label string) color.RGBA {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(label))
+
+	return labelPalette[h.Sum32()%uint32(len(labelPalette))]
+}
+
+// DrawDetectedObjects draws the bounding boxes of the objects onto the image, annotated with
+// their label and score. Each label is drawn in its own color.
+func DrawDetectedObjects(dst *image.RGBA, objects []DetectedObject) {
+	const (
+		thickness = 2
+		scale     = 2
+	)
+
+	for _, object := range objects {
+		c := labelColor(object.Label)
+		r := object.Box.Rect()
+
+		for i := 0; i < thickness; i++ {
+			drawRectOutline(dst, r.Inset(i), c)
+		}
+
+		text := fmt.Sprintf("%s %.2f", object.Label, object.Score)
+		textSize := image.Pt(len(text)*(glyphWidth+1)*scale+scale, (glyphHeight+2)*scale)
+
+		// The annotation is placed above the box, or inside it if there is no space above
+		tab := image.Rectangle{Min: image.Pt(r.Min.X, r.Min.Y-textSize.Y), Max: image.Pt(r.Min.X+textSize.X, r.Min.Y)}
+		if tab.Min.Y < dst.Bounds().Min.Y {
+			tab = tab.Add(image.Pt(0, textSize.Y))
+		}
+
+		draw.Draw(dst, tab.Intersect(dst.Bounds()), &image.Uniform{C: c}, image.Point{}, draw.Src)
+		drawText(dst, tab.Min.Add(image.Pt(scale, scale)), text, scale, color.RGBA{255, 255, 255, 255})
+	}
+}
+
+// drawRectOutline draws the outline of the rectangle onto the image.
+func drawRectOutline(dst *image.RGBA, r image.Rectangle, c color.Color) {
+	if r.Empty() {
+		return
+	}
+
+	for x := r.Min.X; x < r.Max.X; x++ {
+		setPixel(dst, x, r.Min.Y, c)
+		setPixel(dst, x, r.Max.Y-1, c)
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		setPixel(dst, r.Min.X, y, c)
+		setPixel(dst, r.Max.X-1, y, c)
+	}
+}
+
+// setPixel sets the pixel if it is within the bounds of the image.
+func setPixel(dst *image.RGBA, x, y int, c color.Color) {
+	if image.Pt(x, y).In(dst.Bounds()) {
+		dst.Set(x, y, c)
+	}
+}
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+)
+
+// glyphs contains a minimal 3x5 pixel font. Each row of a glyph is stored in the
+// lowest three bits of a byte, with the leftmost pixel in the highest bit.
+var glyphs = map[rune][glyphHeight]byte{
+	'A': {2, 5, 7, 5, 5}, 'B': {6, 5, 6, 5, 6}, 'C': {3, 4, 4, 4, 3}, 'D': {6, 5, 5, 5, 6},
+	'E': {7, 4, 6, 4, 7}, 'F': {7, 4, 6, 4, 4}, 'G': {3, 4, 5, 5, 3}, 'H': {5, 5, 7, 5, 5},
+	'I': {7, 2, 2, 2, 7}, 'J': {1, 1, 1, 5, 2}, 'K': {5, 5, 6, 5, 5}, 'L': {4, 4, 4, 4, 7},
+	'M': {5, 7, 7, 5, 5}, 'N': {6, 5, 5, 5, 5}, 'O': {2, 5, 5, 5, 2}, 'P': {6, 5, 6, 4, 4},
+	'Q': {2, 5, 5, 6, 3}, 'R': {6, 5, 6, 5, 5}, 'S': {3, 4, 2, 1, 6}, 'T': {7, 2, 2, 2, 2},
+	'U': {5, 5, 5, 5, 7}, 'V': {5, 5, 5, 5, 2}, 'W': {5, 5, 7, 7, 5}, 'X': {5, 5, 2, 5, 5},
+	'Y': {5, 5, 2, 2, 2}, 'Z': {7, 1, 2, 4, 7},
+	'0': {7, 5, 5, 5, 7}, '1': {2, 6, 2, 2, 7}, '2': {6, 1, 2, 4, 7}, '3': {6, 1, 2, 1, 6},
+	'4': {5, 5, 7, 1, 1}, '5': {7, 4, 6, 1, 6}, '6': {3, 4, 7, 5, 7}, '7': {7, 1, 2, 2, 2},
+	'8': {7, 5, 7, 5, 7}, '9': {7, 5, 7, 1, 6},
+	'.': {0, 0, 0, 0, 2}, '-': {0, 0, 7, 0, 0}, ':': {0, 2, 0, 2, 0}, '%': {5, 1, 2, 4, 5},
+	'_': {0, 0, 0, 0, 7}, '/': {1, 1, 2, 4, 4}, ' ': {0, 0, 0, 0, 0}, '?': {6, 1, 2, 0, 2},
+}
+
+// drawText draws the text in upper case with the minimal pixel font, scaled by the specified factor.
+func drawText(dst *image.RGBA, at image.Point, text string, scale int, c color.Color) {
+	x := at.X
+
+	for _, r := range strings.ToUpper(text) {
+		glyph, ok := glyphs[r]
+		if !ok {
+			glyph = glyphs['?']
+		}
+
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if glyph[row]&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						setPixel(dst, x+col*scale+dx, at.Y+row*scale+dy, c)
+					}
+				}
+			}
+		}
+
+		x += (glyphWidth + 1) * scale
+	}
+}
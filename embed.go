@@ -0,0 +1,295 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PoolingStrategy specifies how token embeddings are pooled into a single embedding.
+type PoolingStrategy string
+
+const (
+	// PoolingMean averages the embeddings of the tokens, weighted by the attention mask if set.
+	PoolingMean PoolingStrategy = "mean"
+
+	// PoolingCLS uses the embedding of the first token.
+	PoolingCLS PoolingStrategy = "cls"
+
+	// PoolingMax takes the maximum of each dimension over the tokens not masked by the attention mask.
+	PoolingMax PoolingStrategy = "max"
+)
+
+// EmbedOptions represents options for Embed.
+type EmbedOptions struct {
+	// The model used to compute the embeddings.
+	Model string
+
+	// (Default: PoolingMean). The pooling applied if the model returns token embeddings
+	// instead of sentence embeddings.
+	Pooling PoolingStrategy
+
+	// (Optional) The attention mask of the tokens of each text, e.g. returned by a tokenizer together with
+	// the tokens. Tokens with a mask of zero, like padding tokens, are excluded from mean and max pooling.
+	// All tokens are pooled if empty.
+	AttentionMask [][]int
+
+	// (Default: false). Whether the embeddings are L2 normalized by the client.
+	Normalize bool
+
+	// (Default: 0). The expected number of dimensions of the embeddings. Zero accepts any number of
+	// dimensions as long as all embeddings have the same.
+	Dimensions int
+
+	Parameters *FeatureExtractionParameters
+	Options    Options
+}
+
+// Embed computes one embedding for each of the texts using the feature extraction endpoint.
+// Depending on the model the endpoint returns sentence embeddings or the embeddings of each
+// token. Token embeddings are pooled with the configured strategy and attention mask. Without
+// an attention mask all returned tokens are pooled, including padding tokens if the endpoint
+// returns any.
+func (ic *InferenceClient) Embed(ctx context.Context, texts []string, optFns ...func(o *EmbedOptions)) ([][]float32, error) {
+	opts := EmbedOptions{
+		Pooling: PoolingMean,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if len(texts) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	if len(opts.AttentionMask) > 0 && len(opts.AttentionMask) != len(texts) {
+		return nil, fmt.Errorf("expected an attention mask for each of the %d inputs, got %d", len(texts), len(opts.AttentionMask))
+	}
+
+	body, err := ic.post(ctx, opts.Model, "feature-extraction", &FeatureExtractionRequest{
+		Inputs:     texts,
+		Parameters: opts.Parameters,
+		Options:    opts.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := decodeEmbeddings(body, len(texts), opts.Pooling, opts.AttentionMask)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddings))
+	}
+
+	dimensions := opts.Dimensions
+	if dimensions == 0 {
+		dimensions = len(embeddings[0])
+	}
+
+	for i, embedding := range embeddings {
+		if len(embedding) != dimensions {
+			return nil, fmt.Errorf("embedding %d has %d dimensions, expected %d", i, len(embedding), dimensions)
+		}
+
+		if opts.Normalize {
+			normalizeL2(embedding)
+		}
+	}
+
+	return embeddings, nil
+}
+
+// decodeEmbeddings decodes the features returned by the feature extraction endpoint into one
+// embedding for each input, depending on the rank of the features:
+//
+//	1: [dim] the embedding of a single input
+//	2: [inputs][dim] sentence embeddings, or [tokens][dim] token embeddings of a single input
+//	3: [inputs][tokens][dim] token embeddings
+//	4: [inputs][1][tokens][dim] token embeddings with a batch dimension
+//
+// The attention mask is empty or contains the mask of the tokens of each input.
+func decodeEmbeddings(data []byte, inputs int, pooling PoolingStrategy, attentionMask [][]int) ([][]float32, error) {
+	switch rank := jsonArrayRank(data); rank {
+	case 1:
+		embedding := []float32{}
+		if err := json.Unmarshal(data, &embedding); err != nil {
+			return nil, err
+		}
+
+		return [][]float32{embedding}, nil
+	case 2:
+		features := [][]float32{}
+		if err := json.Unmarshal(data, &features); err != nil {
+			return nil, err
+		}
+
+		if len(features) != inputs && inputs == 1 {
+			embedding, err := poolTokens(features, pooling, inputMask(attentionMask, 0))
+			if err != nil {
+				return nil, err
+			}
+
+			return [][]float32{embedding}, nil
+		}
+
+		return features, nil
+	case 3:
+		features := [][][]float32{}
+		if err := json.Unmarshal(data, &features); err != nil {
+			return nil, err
+		}
+
+		return poolInputs(features, pooling, attentionMask)
+	case 4:
+		features := [][][][]float32{}
+		if err := json.Unmarshal(data, &features); err != nil {
+			return nil, err
+		}
+
+		squeezed := make([][][]float32, len(features))
+
+		for i, f := range features {
+			if len(f) != 1 {
+				return nil, fmt.Errorf("unexpected batch size %d for input %d", len(f), i)
+			}
+
+			squeezed[i] = f[0]
+		}
+
+		return poolInputs(squeezed, pooling, attentionMask)
+	default:
+		return nil, fmt.Errorf("unexpected features of rank %d", rank)
+	}
+}
+
+// jsonArrayRank returns the number of nested arrays at the start of the JSON data.
+func jsonArrayRank(data []byte) int {
+	rank := 0
+
+	for _, b := range bytes.TrimSpace(data) {
+		switch b {
+		case '[':
+			rank++
+		case ' ', '\t', '\r', '\n':
+		default:
+			return rank
+		}
+	}
+
+	return rank
+}
+
+// poolInputs pools the token embeddings of each input.
+func poolInputs(features [][][]float32, pooling PoolingStrategy, attentionMask [][]int) ([][]float32, error) {
+	embeddings := make([][]float32, len(features))
+
+	for i, tokens := range features {
+		embedding, err := poolTokens(tokens, pooling, inputMask(attentionMask, i))
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+
+		embeddings[i] = embedding
+	}
+
+	return embeddings, nil
+}
+
+// inputMask returns the attention mask of the input, or nil if there is no attention mask.
+func inputMask(attentionMask [][]int, input int) []int {
+	if input >= len(attentionMask) {
+		return nil
+	}
+
+	return attentionMask[input]
+}
+
+// poolTokens pools the token embeddings into a single embedding. Tokens with a mask of zero are
+// excluded from mean and max pooling, and all tokens are pooled if the mask is nil.
+func poolTokens(tokens [][]float32, pooling PoolingStrategy, mask []int) ([]float32, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("no token embeddings")
+	}
+
+	if mask != nil && len(mask) != len(tokens) {
+		return nil, fmt.Errorf("attention mask has %d tokens, expected %d", len(mask), len(tokens))
+	}
+
+	dimensions := len(tokens[0])
+	for _, token := range tokens {
+		if len(token) != dimensions {
+			return nil, fmt.Errorf("token embeddings have different dimensions %d and %d", dimensions, len(token))
+		}
+	}
+
+	attended := tokens
+
+	if mask != nil {
+		attended = make([][]float32, 0, len(tokens))
+
+		for i, token := range tokens {
+			if mask[i] != 0 {
+				attended = append(attended, token)
+			}
+		}
+
+		if len(attended) == 0 && pooling != PoolingCLS {
+			return nil, errors.New("all tokens are masked")
+		}
+	}
+
+	embedding := make([]float32, dimensions)
+
+	switch pooling {
+	case PoolingMean, "":
+		for _, token := range attended {
+			for j, v := range token {
+				embedding[j] += v
+			}
+		}
+
+		for j := range embedding {
+			embedding[j] /= float32(len(attended))
+		}
+	case PoolingCLS:
+		copy(embedding, tokens[0])
+	case PoolingMax:
+		copy(embedding, attended[0])
+
+		for _, token := range attended[1:] {
+			for j, v := range token {
+				if v > embedding[j] {
+					embedding[j] = v
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown pooling strategy %q", pooling)
+	}
+
+	return embedding, nil
+}
+
+// normalizeL2 scales the vector in place to unit length. Zero vectors are left unchanged.
+func normalizeL2(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+
+	if sum == 0 {
+		return
+	}
+
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+}
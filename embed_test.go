@@ -0,0 +1,148 @@
+package huggingface
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbed(t *testing.T) {
+	tests := []struct {
+		name     string
+		texts    []string
+		response string
+		pooling  PoolingStrategy
+		expected [][]float32
+	}{
+		{"Rank 1", []string{"a"}, `[1, 2]`, PoolingMean, [][]float32{{1, 2}}},
+		{"Rank 2 sentence embeddings", []string{"a", "b"}, `[[1, 2], [3, 4]]`, PoolingMean, [][]float32{{1, 2}, {3, 4}}},
+		{"Rank 2 token embeddings", []string{"a"}, `[[1, 2], [3, 6]]`, PoolingMean, [][]float32{{2, 4}}},
+		{"Rank 3 mean", []string{"a", "b"}, `[[[1, 2], [3, 6]], [[1, 1]]]`, PoolingMean, [][]float32{{2, 4}, {1, 1}}},
+		{"Rank 3 cls", []string{"a"}, `[[[1, 2], [3, 6]]]`, PoolingCLS, [][]float32{{1, 2}}},
+		{"Rank 4 max", []string{"a"}, `[[[[1, 7], [3, 6]]]]`, PoolingMax, [][]float32{{3, 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/pipeline/feature-extraction/model", r.URL.Path)
+
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+				o.InferenceEndpoint = server.URL
+			})
+
+			embeddings, err := client.Embed(context.Background(), tt.texts, func(o *EmbedOptions) {
+				o.Model = "model"
+				o.Pooling = tt.pooling
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, embeddings)
+		})
+	}
+
+	t.Run("Parameters", func(t *testing.T) {
+		payloads := []string{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			payloads = append(payloads, string(body))
+
+			_, _ = w.Write([]byte(`[[1, 2]]`))
+		}))
+		defer server.Close()
+
+		client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+			o.InferenceEndpoint = server.URL
+		})
+
+		_, err := client.Embed(context.Background(), []string{"a"}, func(o *EmbedOptions) {
+			o.Model = "model"
+		})
+		assert.NoError(t, err)
+
+		_, err = client.Embed(context.Background(), []string{"a"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.Parameters = &FeatureExtractionParameters{PromptName: "query", Truncate: PTR(true)}
+		})
+		assert.NoError(t, err)
+
+		assert.Len(t, payloads, 2)
+		assert.JSONEq(t, `{"inputs":["a"],"options":{}}`, payloads[0])
+		assert.JSONEq(t, `{"inputs":["a"],"parameters":{"prompt_name":"query","truncate":true},"options":{}}`, payloads[1])
+	})
+
+	t.Run("Attention mask", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The second input is padded to the length of the first
+			_, _ = w.Write([]byte(`[[[1, 2], [3, 6], [5, 1]], [[1, 1], [7, 7], [0, 0]]]`))
+		}))
+		defer server.Close()
+
+		client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+			o.InferenceEndpoint = server.URL
+		})
+
+		mask := [][]int{{1, 1, 1}, {1, 0, 0}}
+
+		embeddings, err := client.Embed(context.Background(), []string{"a", "b"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.AttentionMask = mask
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float32{{3, 3}, {1, 1}}, embeddings)
+
+		embeddings, err = client.Embed(context.Background(), []string{"a", "b"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.Pooling = PoolingMax
+			o.AttentionMask = mask
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float32{{5, 6}, {1, 1}}, embeddings)
+
+		_, err = client.Embed(context.Background(), []string{"a", "b"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.AttentionMask = [][]int{{1, 1, 1}, {1, 0}}
+		})
+		assert.EqualError(t, err, "input 1: attention mask has 2 tokens, expected 3")
+
+		_, err = client.Embed(context.Background(), []string{"a", "b"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.AttentionMask = [][]int{{1, 1, 1}}
+		})
+		assert.EqualError(t, err, "expected an attention mask for each of the 2 inputs, got 1")
+	})
+
+	t.Run("Normalize and check dimensions", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[[3, 4]]`))
+		}))
+		defer server.Close()
+
+		client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+			o.InferenceEndpoint = server.URL
+		})
+
+		embeddings, err := client.Embed(context.Background(), []string{"a"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.Normalize = true
+		})
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float32{0.6, 0.8}, embeddings[0], 1e-6)
+
+		_, err = client.Embed(context.Background(), []string{"a"}, func(o *EmbedOptions) {
+			o.Model = "model"
+			o.Dimensions = 3
+		})
+		assert.EqualError(t, err, "embedding 0 has 2 dimensions, expected 3")
+	})
+}
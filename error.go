@@ -1,5 +1,34 @@
 package huggingface
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// HTTPError is returned when a Hugging Face service responds with a non-2xx status code.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the error reported by the service or the raw response body.
+	Message string
+}
+
+// Error returns the error message.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("huggingfaces error: %s", e.Message)
+}
+
+// newHTTPError creates an HTTPError from the status code and body of a failed response.
+func newHTTPError(statusCode int, body []byte) *HTTPError {
+	errResp := ErrorResponse{}
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == "" {
+		return &HTTPError{StatusCode: statusCode, Message: string(body)}
+	}
+
+	return &HTTPError{StatusCode: statusCode, Message: errResp.Error}
+}
@@ -6,12 +6,28 @@ import (
 	"errors"
 )
 
+// Used with FeatureExtractionRequest
+type FeatureExtractionParameters struct {
+	// The name of the prompt of the model configuration that is prepended to the inputs.
+	PromptName string `json:"prompt_name,omitempty"`
+
+	// (Default: None). Bool. Whether the embeddings are L2 normalized by the server.
+	Normalize *bool `json:"normalize,omitempty"`
+
+	// (Default: None). Bool. Whether inputs longer than the maximum length of the model are truncated.
+	Truncate *bool `json:"truncate,omitempty"`
+
+	// (Default: Right). The side of the inputs that is truncated.
+	TruncationDirection TruncationDirection `json:"truncation_direction,omitempty"`
+}
+
 // Request structure for the feature extraction endpoint
 type FeatureExtractionRequest struct {
 	// String to get the features from
-	Inputs  []string `json:"inputs"`
-	Options Options  `json:"options,omitempty"`
-	Model   string   `json:"-"`
+	Inputs     []string                     `json:"inputs"`
+	Parameters *FeatureExtractionParameters `json:"parameters,omitempty"`
+	Options    Options                      `json:"options,omitempty"`
+	Model      string                       `json:"-"`
 }
 
 // Response structure for the feature extraction endpoint
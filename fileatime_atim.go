@@ -0,0 +1,18 @@
+//go:build linux || openbsd || dragonfly || solaris
+
+package huggingface
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileAccessTime returns the time the file was last accessed, or its modification time if unknown.
+func fileAccessTime(fi os.FileInfo) time.Time {
+	if stat, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return time.Unix(int64(stat.Atim.Sec), int64(stat.Atim.Nsec)) //nolint:unconvert
+	}
+
+	return fi.ModTime()
+}
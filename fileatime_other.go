@@ -0,0 +1,13 @@
+//go:build !(linux || openbsd || dragonfly || solaris || darwin || freebsd || netbsd)
+
+package huggingface
+
+import (
+	"os"
+	"time"
+)
+
+// fileAccessTime returns the modification time of the file, since the access time is not available.
+func fileAccessTime(fi os.FileInfo) time.Time {
+	return fi.ModTime()
+}
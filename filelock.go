@@ -0,0 +1,33 @@
+package huggingface
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockFile acquires an exclusive lock on the file at the specified path, waiting until the lock
+// is released by other processes or the context is done. The returned function releases the lock.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	for {
+		f, err := tryLockFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if f != nil {
+			return func() { _ = unlockFile(f) }, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
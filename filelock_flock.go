@@ -0,0 +1,37 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package huggingface
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile tries to acquire an exclusive lock on the file at the specified path without blocking.
+// It returns a nil file if the lock is held by another process.
+func tryLockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// unlockFile releases the lock acquired with tryLockFile.
+func unlockFile(f *os.File) error {
+	defer f.Close()
+
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,73 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package huggingface
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// lockRefreshInterval is the interval at which the modification time of held locks is updated.
+const lockRefreshInterval = time.Minute
+
+// staleLockAge is the age after which a lock is considered to be left behind by a process that
+// exited without releasing it.
+const staleLockAge = 5 * lockRefreshInterval
+
+// lockRefreshes contains the channels that stop the refresh of the held locks.
+var lockRefreshes sync.Map
+
+// tryLockFile tries to acquire an exclusive lock on the file at the specified path without blocking.
+// It returns a nil file if the lock is held by another process. The lock is represented by the
+// existence of the file, whose modification time is updated while the lock is held. Stale locks
+// are removed.
+func tryLockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o644)
+	if err != nil {
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	}
+
+	stop := make(chan struct{})
+	lockRefreshes.Store(f, stop)
+
+	go func() {
+		ticker := time.NewTicker(lockRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				_ = os.Chtimes(path, now, now)
+			}
+		}
+	}()
+
+	return f, nil
+}
+
+// unlockFile releases the lock acquired with tryLockFile.
+func unlockFile(f *os.File) error {
+	if stop, ok := lockRefreshes.LoadAndDelete(f); ok {
+		close(stop.(chan struct{}))
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(f.Name())
+}
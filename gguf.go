@@ -0,0 +1,718 @@
+package huggingface
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// ggufMagic is the magic number at the start of GGUF files ("GGUF" in little-endian byte order).
+const ggufMagic = 0x46554747
+
+// ggufDefaultAlignment is the alignment of the tensor data if general.alignment is not set.
+const ggufDefaultAlignment = 32
+
+// ggufReadChunkSize is the number of bytes that are read at once while decoding the header,
+// which keeps the number of range requests for remote files small.
+const ggufReadChunkSize = 1024 * 1024
+
+// Limits that protect against allocating memory for corrupt headers.
+const (
+	maxGGUFStringLength = 64 * 1024 * 1024
+	maxGGUFDimensions   = 4
+)
+
+// Types of the metadata values of GGUF files.
+const (
+	ggufTypeUint8   = 0
+	ggufTypeInt8    = 1
+	ggufTypeUint16  = 2
+	ggufTypeInt16   = 3
+	ggufTypeUint32  = 4
+	ggufTypeInt32   = 5
+	ggufTypeFloat32 = 6
+	ggufTypeBool    = 7
+	ggufTypeString  = 8
+	ggufTypeArray   = 9
+	ggufTypeUint64  = 10
+	ggufTypeInt64   = 11
+	ggufTypeFloat64 = 12
+)
+
+// GGMLType is the data type of a tensor of a GGUF file, e.g. F16 or Q4_K.
+type GGMLType uint32
+
+// ggmlTypeInfo describes the blocks of a data type. Quantized types store blockSize elements in
+// typeSize bytes.
+type ggmlTypeInfo struct {
+	name      string
+	blockSize uint64
+	typeSize  uint64
+}
+
+var ggmlTypes = map[GGMLType]ggmlTypeInfo{
+	0:  {"F32", 1, 4},
+	1:  {"F16", 1, 2},
+	2:  {"Q4_0", 32, 18},
+	3:  {"Q4_1", 32, 20},
+	6:  {"Q5_0", 32, 22},
+	7:  {"Q5_1", 32, 24},
+	8:  {"Q8_0", 32, 34},
+	9:  {"Q8_1", 32, 36},
+	10: {"Q2_K", 256, 84},
+	11: {"Q3_K", 256, 110},
+	12: {"Q4_K", 256, 144},
+	13: {"Q5_K", 256, 176},
+	14: {"Q6_K", 256, 210},
+	15: {"Q8_K", 256, 292},
+	16: {"IQ2_XXS", 256, 66},
+	17: {"IQ2_XS", 256, 74},
+	18: {"IQ3_XXS", 256, 98},
+	19: {"IQ1_S", 256, 50},
+	20: {"IQ4_NL", 32, 18},
+	21: {"IQ3_S", 256, 110},
+	22: {"IQ2_S", 256, 82},
+	23: {"IQ4_XS", 256, 136},
+	24: {"I8", 1, 1},
+	25: {"I16", 1, 2},
+	26: {"I32", 1, 4},
+	27: {"I64", 1, 8},
+	28: {"F64", 1, 8},
+	29: {"IQ1_M", 256, 56},
+	30: {"BF16", 1, 2},
+	34: {"TQ1_0", 256, 54},
+	35: {"TQ2_0", 256, 66},
+	39: {"MXFP4", 32, 17},
+}
+
+// String returns the name of the data type, e.g. Q4_K.
+func (t GGMLType) String() string {
+	if info, ok := ggmlTypes[t]; ok {
+		return info.name
+	}
+
+	return fmt.Sprintf("GGMLType(%d)", uint32(t))
+}
+
+// ggufFileTypes contains the names of the values of general.file_type, which describes the
+// predominant quantization of the tensors.
+var ggufFileTypes = map[uint64]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	19: "IQ2_XXS",
+	20: "IQ2_XS",
+	21: "Q2_K_S",
+	22: "IQ3_XS",
+	23: "IQ3_XXS",
+	24: "IQ1_S",
+	25: "IQ4_NL",
+	26: "IQ3_S",
+	27: "IQ3_M",
+	28: "IQ2_S",
+	29: "IQ2_M",
+	30: "IQ4_XS",
+	31: "IQ1_M",
+	32: "BF16",
+	36: "TQ1_0",
+	37: "TQ2_0",
+	38: "MXFP4_MOE",
+}
+
+// GGUFTensorInfo describes a tensor of a GGUF file.
+type GGUFTensorInfo struct {
+	// The name of the tensor.
+	Name string
+
+	// The data type of the tensor.
+	Type GGMLType
+
+	// The dimensions of the tensor, starting with the innermost dimension.
+	Shape []uint64
+
+	// The offset of the data of the tensor, relative to the start of the tensor data.
+	Offset uint64
+}
+
+// NumElements returns the number of elements of the tensor.
+func (ti GGUFTensorInfo) NumElements() uint64 {
+	n := uint64(1)
+	for _, dim := range ti.Shape {
+		n *= dim
+	}
+
+	return n
+}
+
+// Size returns the size of the data of the tensor in bytes.
+func (ti GGUFTensorInfo) Size() uint64 {
+	info := ggmlTypes[ti.Type]
+	if info.blockSize == 0 {
+		return 0
+	}
+
+	return ti.NumElements() / info.blockSize * info.typeSize
+}
+
+// GGUFFile contains the header of a GGUF file.
+type GGUFFile struct {
+	// The version of the file format.
+	Version uint32
+
+	// The key-value metadata, e.g. general.architecture. Arrays are decoded to slices of the element
+	// type, e.g. []string for tokenizer.ggml.tokens.
+	Metadata map[string]any
+
+	// The tensors in the order of the header.
+	Tensors []GGUFTensorInfo
+
+	// The alignment of the tensor data in bytes.
+	Alignment uint64
+
+	// The offset of the tensor data in the file.
+	DataOffset int64
+}
+
+// Architecture returns the architecture of the model, e.g. llama.
+func (f *GGUFFile) Architecture() string {
+	return f.metadataString("general.architecture")
+}
+
+// Name returns the name of the model.
+func (f *GGUFFile) Name() string {
+	return f.metadataString("general.name")
+}
+
+// ContextLength returns the context length the model was trained with, or 0 if it is unknown.
+func (f *GGUFFile) ContextLength() uint64 {
+	n, _ := ggufUint(f.Metadata[f.Architecture()+".context_length"])
+	return n
+}
+
+// ChatTemplate returns the Jinja chat template of the tokenizer, or an empty string if the model has none.
+func (f *GGUFFile) ChatTemplate() string {
+	return f.metadataString("tokenizer.chat_template")
+}
+
+// VocabSize returns the number of tokens of the vocabulary, or 0 if it is unknown.
+func (f *GGUFFile) VocabSize() uint64 {
+	if tokens, ok := f.Metadata["tokenizer.ggml.tokens"].([]string); ok {
+		return uint64(len(tokens))
+	}
+
+	n, _ := ggufUint(f.Metadata[f.Architecture()+".vocab_size"])
+
+	return n
+}
+
+// QuantizationType returns the quantization of the model, e.g. Q4_K_M. If general.file_type is not
+// set, the data type of the majority of the parameters is returned.
+func (f *GGUFFile) QuantizationType() string {
+	if fileType, ok := ggufUint(f.Metadata["general.file_type"]); ok {
+		if name, ok := ggufFileTypes[fileType]; ok {
+			return name
+		}
+
+		return fmt.Sprintf("unknown (%d)", fileType)
+	}
+
+	counts := map[GGMLType]uint64{}
+	for _, tensor := range f.Tensors {
+		counts[tensor.Type] += tensor.NumElements()
+	}
+
+	var (
+		predominant GGMLType
+		most        uint64
+	)
+
+	for typ, n := range counts {
+		if n > most || (n == most && typ < predominant) {
+			predominant, most = typ, n
+		}
+	}
+
+	if most == 0 {
+		return ""
+	}
+
+	return predominant.String()
+}
+
+// TotalParameters returns the number of elements of all tensors.
+func (f *GGUFFile) TotalParameters() uint64 {
+	total := uint64(0)
+	for _, tensor := range f.Tensors {
+		total += tensor.NumElements()
+	}
+
+	return total
+}
+
+// DataSize returns the size of the tensor data in bytes, i.e. the end of the last tensor.
+func (f *GGUFFile) DataSize() uint64 {
+	size := uint64(0)
+
+	for _, tensor := range f.Tensors {
+		if end := tensor.Offset + tensor.Size(); end > size {
+			size = end
+		}
+	}
+
+	return size
+}
+
+func (f *GGUFFile) metadataString(key string) string {
+	s, _ := f.Metadata[key].(string)
+	return s
+}
+
+// ReadGGUF reads and validates the header of the GGUF file from r, which contains the metadata and
+// the tensor index. The tensor data is not read.
+func ReadGGUF(r io.ReaderAt) (*GGUFFile, error) {
+	d := &ggufDecoder{r: bufio.NewReaderSize(io.NewSectionReader(r, 0, math.MaxInt64), ggufReadChunkSize)}
+
+	f, err := d.readFile()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, fmt.Errorf("gguf: %w", err)
+	}
+
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("gguf: %w", err)
+	}
+
+	return f, nil
+}
+
+// ReadGGUFFile reads the header of the named GGUF file and checks that the file contains the data of all tensors.
+func ReadGGUFFile(name string) (*GGUFFile, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	f, err := ReadGGUF(file)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if stat.Size() < f.DataOffset || f.DataSize() > uint64(stat.Size()-f.DataOffset) {
+		return nil, fmt.Errorf("gguf: file is truncated, the tensor data requires %d bytes", f.DataSize())
+	}
+
+	return f, nil
+}
+
+// validate checks the data types, shapes and offsets of the tensors.
+func (f *GGUFFile) validate() error {
+	names := make(map[string]bool, len(f.Tensors))
+
+	for _, tensor := range f.Tensors {
+		if names[tensor.Name] {
+			return fmt.Errorf("duplicate tensor %q", tensor.Name)
+		}
+
+		names[tensor.Name] = true
+
+		info, ok := ggmlTypes[tensor.Type]
+		if !ok {
+			return fmt.Errorf("tensor %q has unknown type %s", tensor.Name, tensor.Type)
+		}
+
+		if len(tensor.Shape) == 0 || tensor.Shape[0]%info.blockSize != 0 {
+			return fmt.Errorf("tensor %q of type %s has invalid shape %v", tensor.Name, tensor.Type, tensor.Shape)
+		}
+
+		if tensor.Offset%f.Alignment != 0 {
+			return fmt.Errorf("tensor %q has unaligned offset %d", tensor.Name, tensor.Offset)
+		}
+	}
+
+	sorted := append([]GGUFTensorInfo{}, f.Tensors...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if prev := sorted[i-1]; prev.Offset+prev.Size() > sorted[i].Offset {
+			return fmt.Errorf("tensors %q and %q overlap", prev.Name, sorted[i].Name)
+		}
+	}
+
+	return nil
+}
+
+// ggufDecoder decodes the header of a GGUF file.
+type ggufDecoder struct {
+	r      *bufio.Reader
+	offset int64
+	buf    [8]byte
+}
+
+func (d *ggufDecoder) readFile() (*GGUFFile, error) {
+	magic, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	if magic != ggufMagic {
+		return nil, errors.New("invalid magic number")
+	}
+
+	f := &GGUFFile{Metadata: map[string]any{}, Alignment: ggufDefaultAlignment}
+
+	if f.Version, err = d.readUint32(); err != nil {
+		return nil, err
+	}
+
+	if f.Version&0xffff == 0 {
+		return nil, errors.New("big-endian files are not supported")
+	}
+
+	if f.Version != 2 && f.Version != 3 {
+		return nil, fmt.Errorf("unsupported version %d", f.Version)
+	}
+
+	tensorCount, err := d.readUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	kvCount, err := d.readUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := uint64(0); i < kvCount; i++ {
+		key, err := d.readString()
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := d.readValue(typ)
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q: %w", key, err)
+		}
+
+		f.Metadata[key] = value
+	}
+
+	if alignment, ok := f.Metadata["general.alignment"]; ok {
+		n, _ := ggufUint(alignment)
+		if n == 0 || n&(n-1) != 0 {
+			return nil, fmt.Errorf("invalid alignment %v", alignment)
+		}
+
+		f.Alignment = n
+	}
+
+	for i := uint64(0); i < tensorCount; i++ {
+		tensor, err := d.readTensorInfo()
+		if err != nil {
+			return nil, err
+		}
+
+		f.Tensors = append(f.Tensors, tensor)
+	}
+
+	alignment := int64(f.Alignment)
+	f.DataOffset = (d.offset + alignment - 1) / alignment * alignment
+
+	return f, nil
+}
+
+func (d *ggufDecoder) readTensorInfo() (GGUFTensorInfo, error) {
+	tensor := GGUFTensorInfo{}
+
+	var err error
+
+	if tensor.Name, err = d.readString(); err != nil {
+		return tensor, err
+	}
+
+	dims, err := d.readUint32()
+	if err != nil {
+		return tensor, err
+	}
+
+	if dims > maxGGUFDimensions {
+		return tensor, fmt.Errorf("tensor %q has %d dimensions", tensor.Name, dims)
+	}
+
+	tensor.Shape = make([]uint64, dims)
+	for i := range tensor.Shape {
+		if tensor.Shape[i], err = d.readUint64(); err != nil {
+			return tensor, err
+		}
+	}
+
+	typ, err := d.readUint32()
+	if err != nil {
+		return tensor, err
+	}
+
+	tensor.Type = GGMLType(typ)
+	tensor.Offset, err = d.readUint64()
+
+	return tensor, err
+}
+
+// readValue reads a metadata value of the specified type.
+func (d *ggufDecoder) readValue(typ uint32) (any, error) {
+	switch typ {
+	case ggufTypeUint8:
+		return d.readUint8()
+	case ggufTypeInt8:
+		v, err := d.readUint8()
+		return int8(v), err
+	case ggufTypeUint16:
+		return d.readUint16()
+	case ggufTypeInt16:
+		v, err := d.readUint16()
+		return int16(v), err
+	case ggufTypeUint32:
+		return d.readUint32()
+	case ggufTypeInt32:
+		v, err := d.readUint32()
+		return int32(v), err
+	case ggufTypeFloat32:
+		v, err := d.readUint32()
+		return math.Float32frombits(v), err
+	case ggufTypeBool:
+		v, err := d.readUint8()
+		return v != 0, err
+	case ggufTypeString:
+		return d.readString()
+	case ggufTypeArray:
+		return d.readArray()
+	case ggufTypeUint64:
+		return d.readUint64()
+	case ggufTypeInt64:
+		v, err := d.readUint64()
+		return int64(v), err
+	case ggufTypeFloat64:
+		v, err := d.readUint64()
+		return math.Float64frombits(v), err
+	default:
+		return nil, fmt.Errorf("unknown value type %d", typ)
+	}
+}
+
+// readArray reads an array, which is decoded to a slice of the element type, e.g. []string.
+// Arrays of arrays are decoded to []any.
+func (d *ggufDecoder) readArray() (any, error) {
+	typ, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := d.readUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case ggufTypeUint8:
+		return readGGUFArray(n, d.readUint8)
+	case ggufTypeInt8:
+		return readGGUFArray(n, func() (int8, error) {
+			v, err := d.readUint8()
+			return int8(v), err
+		})
+	case ggufTypeUint16:
+		return readGGUFArray(n, d.readUint16)
+	case ggufTypeInt16:
+		return readGGUFArray(n, func() (int16, error) {
+			v, err := d.readUint16()
+			return int16(v), err
+		})
+	case ggufTypeUint32:
+		return readGGUFArray(n, d.readUint32)
+	case ggufTypeInt32:
+		return readGGUFArray(n, func() (int32, error) {
+			v, err := d.readUint32()
+			return int32(v), err
+		})
+	case ggufTypeFloat32:
+		return readGGUFArray(n, func() (float32, error) {
+			v, err := d.readUint32()
+			return math.Float32frombits(v), err
+		})
+	case ggufTypeBool:
+		return readGGUFArray(n, func() (bool, error) {
+			v, err := d.readUint8()
+			return v != 0, err
+		})
+	case ggufTypeString:
+		return readGGUFArray(n, d.readString)
+	case ggufTypeUint64:
+		return readGGUFArray(n, d.readUint64)
+	case ggufTypeInt64:
+		return readGGUFArray(n, func() (int64, error) {
+			v, err := d.readUint64()
+			return int64(v), err
+		})
+	case ggufTypeFloat64:
+		return readGGUFArray(n, func() (float64, error) {
+			v, err := d.readUint64()
+			return math.Float64frombits(v), err
+		})
+	default:
+		return readGGUFArray(n, func() (any, error) {
+			return d.readValue(typ)
+		})
+	}
+}
+
+// readGGUFArray reads n elements with the read function. The capacity of the slice grows while
+// reading, so that a corrupt length does not allocate the memory up front.
+func readGGUFArray[T any](n uint64, read func() (T, error)) ([]T, error) {
+	values := make([]T, 0, minUint64(n, 1024))
+
+	for i := uint64(0); i < n; i++ {
+		v, err := read()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func (d *ggufDecoder) read(n int) ([]byte, error) {
+	if _, err := io.ReadFull(d.r, d.buf[:n]); err != nil {
+		return nil, err
+	}
+
+	d.offset += int64(n)
+
+	return d.buf[:n], nil
+}
+
+func (d *ggufDecoder) readUint8() (uint8, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+func (d *ggufDecoder) readUint16() (uint16, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (d *ggufDecoder) readUint32() (uint32, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (d *ggufDecoder) readUint64() (uint64, error) {
+	b, err := d.read(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *ggufDecoder) readString() (string, error) {
+	n, err := d.readUint64()
+	if err != nil {
+		return "", err
+	}
+
+	if n > maxGGUFStringLength {
+		return "", fmt.Errorf("string of %d bytes exceeds the limit", n)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return "", err
+	}
+
+	d.offset += int64(n)
+
+	return string(b), nil
+}
+
+// ggufUint converts an unsigned or non-negative integer metadata value to uint64.
+func ggufUint(value any) (uint64, bool) {
+	switch v := value.(type) {
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case int8:
+		return ggufUint(int64(v))
+	case int16:
+		return ggufUint(int64(v))
+	case int32:
+		return ggufUint(int64(v))
+	case int64:
+		if v < 0 {
+			return 0, false
+		}
+
+		return uint64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
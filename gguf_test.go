@@ -0,0 +1,236 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ggufWriter encodes the header of GGUF files for tests.
+type ggufWriter struct {
+	bytes.Buffer
+}
+
+func (w *ggufWriter) uint32(v uint32) { _ = binary.Write(w, binary.LittleEndian, v) }
+
+func (w *ggufWriter) uint64(v uint64) { _ = binary.Write(w, binary.LittleEndian, v) }
+
+func (w *ggufWriter) string(s string) {
+	w.uint64(uint64(len(s)))
+	w.WriteString(s)
+}
+
+func (w *ggufWriter) kv(key string, typ uint32, value any) {
+	w.string(key)
+	w.uint32(typ)
+
+	switch v := value.(type) {
+	case string:
+		w.string(v)
+	case []string:
+		w.uint32(ggufTypeString)
+		w.uint64(uint64(len(v)))
+
+		for _, s := range v {
+			w.string(s)
+		}
+	case []float32:
+		w.uint32(ggufTypeFloat32)
+		w.uint64(uint64(len(v)))
+		_ = binary.Write(w, binary.LittleEndian, v)
+	default:
+		_ = binary.Write(w, binary.LittleEndian, v)
+	}
+}
+
+func (w *ggufWriter) tensor(name string, typ GGMLType, shape []uint64, offset uint64) {
+	w.string(name)
+	w.uint32(uint32(len(shape)))
+
+	for _, dim := range shape {
+		w.uint64(dim)
+	}
+
+	w.uint32(uint32(typ))
+	w.uint64(offset)
+}
+
+// testGGUF returns a GGUF file of a small llama model with a Q4_K and a F32 tensor.
+func testGGUF(vocabSize int) []byte {
+	tokens := make([]string, vocabSize)
+	scores := make([]float32, vocabSize)
+
+	for i := range tokens {
+		tokens[i] = strings.Repeat("t", i%16+1)
+		scores[i] = float32(i)
+	}
+
+	w := &ggufWriter{}
+	w.uint32(ggufMagic)
+	w.uint32(3)
+	w.uint64(2)
+	w.uint64(8)
+	w.kv("general.architecture", ggufTypeString, "llama")
+	w.kv("general.name", ggufTypeString, "Tiny Llama")
+	w.kv("general.file_type", ggufTypeUint32, uint32(15))
+	w.kv("llama.context_length", ggufTypeUint32, uint32(4096))
+	w.kv("llama.rope.freq_base", ggufTypeFloat32, float32(10000))
+	w.kv("tokenizer.chat_template", ggufTypeString, "{{ messages }}")
+	w.kv("tokenizer.ggml.tokens", ggufTypeArray, tokens)
+	w.kv("tokenizer.ggml.scores", ggufTypeArray, scores)
+	w.tensor("token_embd.weight", 12, []uint64{256, 4}, 0)
+	w.tensor("output_norm.weight", 0, []uint64{256}, 576)
+
+	for w.Len()%32 != 0 {
+		w.WriteByte(0)
+	}
+
+	w.Write(make([]byte, 576+1024))
+
+	return w.Bytes()
+}
+
+func TestReadGGUF(t *testing.T) {
+	data := testGGUF(100)
+
+	name := filepath.Join(t.TempDir(), "model.gguf")
+	assert.NoError(t, os.WriteFile(name, data, 0o600))
+
+	f, err := ReadGGUFFile(name)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), f.Version)
+	assert.Equal(t, "llama", f.Architecture())
+	assert.Equal(t, "Tiny Llama", f.Name())
+	assert.Equal(t, uint64(4096), f.ContextLength())
+	assert.Equal(t, "{{ messages }}", f.ChatTemplate())
+	assert.Equal(t, uint64(100), f.VocabSize())
+	assert.Equal(t, "Q4_K_M", f.QuantizationType())
+	assert.Equal(t, float32(10000), f.Metadata["llama.rope.freq_base"])
+	assert.Equal(t, float32(99), f.Metadata["tokenizer.ggml.scores"].([]float32)[99])
+	assert.Equal(t, uint64(32), f.Alignment)
+	assert.Equal(t, int64(len(data)-576-1024), f.DataOffset)
+	assert.Equal(t, uint64(1280), f.TotalParameters())
+	assert.Equal(t, uint64(576+1024), f.DataSize())
+
+	assert.Equal(t, "token_embd.weight", f.Tensors[0].Name)
+	assert.Equal(t, "Q4_K", f.Tensors[0].Type.String())
+	assert.Equal(t, []uint64{256, 4}, f.Tensors[0].Shape)
+	assert.Equal(t, uint64(576), f.Tensors[0].Size())
+
+	delete(f.Metadata, "general.file_type")
+	assert.Equal(t, "Q4_K", f.QuantizationType())
+
+	assert.NoError(t, os.WriteFile(name, data[:len(data)-1], 0o600))
+
+	_, err = ReadGGUFFile(name)
+	assert.EqualError(t, err, "gguf: file is truncated, the tensor data requires 1600 bytes")
+
+	_, err = ReadGGUF(bytes.NewReader(data[:100]))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = ReadGGUF(bytes.NewReader([]byte("GGML")))
+	assert.EqualError(t, err, "gguf: invalid magic number")
+}
+
+func TestReadGGUFInvalidTensors(t *testing.T) {
+	header := func(tensors func(w *ggufWriter)) []byte {
+		w := &ggufWriter{}
+		w.uint32(ggufMagic)
+		w.uint32(3)
+		w.uint64(2)
+		w.uint64(0)
+		tensors(w)
+
+		return w.Bytes()
+	}
+
+	for name, tc := range map[string]struct {
+		tensors func(w *ggufWriter)
+		err     string
+	}{
+		"overlap": {func(w *ggufWriter) {
+			w.tensor("a", 0, []uint64{16}, 0)
+			w.tensor("b", 0, []uint64{16}, 32)
+		}, `gguf: tensors "a" and "b" overlap`},
+		"unaligned": {func(w *ggufWriter) {
+			w.tensor("a", 0, []uint64{16}, 0)
+			w.tensor("b", 0, []uint64{16}, 68)
+		}, `gguf: tensor "b" has unaligned offset 68`},
+		"block size": {func(w *ggufWriter) {
+			w.tensor("a", 8, []uint64{16}, 0)
+			w.tensor("b", 0, []uint64{16}, 64)
+		}, `gguf: tensor "a" of type Q8_0 has invalid shape [16]`},
+		"unknown type": {func(w *ggufWriter) {
+			w.tensor("a", 99, []uint64{16}, 0)
+			w.tensor("b", 0, []uint64{16}, 64)
+		}, `gguf: tensor "a" has unknown type GGMLType(99)`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadGGUF(bytes.NewReader(header(tc.tensors)))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestGGUFMetadata(t *testing.T) {
+	// The vocabulary makes the header larger than a single read of 1 MiB
+	data := testGGUF(80000)
+	assert.Greater(t, len(data), ggufReadChunkSize)
+
+	server := newTestHub(t, map[string]hubFile{
+		"model-Q4_K_M.gguf": {content: string(data), lfs: true},
+	})
+	defer server.Close()
+
+	client := NewHubClient("", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	f, err := client.GGUFMetadata(context.Background(), "org/model", "model-Q4_K_M.gguf", "")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(80000), f.VocabSize())
+	assert.Equal(t, "Q4_K_M", f.QuantizationType())
+	assert.Len(t, f.Tensors, 2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&server.rangeRequests))
+
+	_, err = client.GGUFMetadata(context.Background(), "org/model", "missing.gguf", "")
+	assert.True(t, isNotFound(err))
+
+	_, err = client.GGUFMetadata(context.Background(), "org/model", "", "")
+	assert.EqualError(t, err, "filename is required")
+}
+
+func TestListGGUFFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/models/org/model-GGUF/tree/main", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("recursive"))
+
+		_, _ = w.Write([]byte(`[
+			{"type":"file","oid":"a","size":10,"path":"README.md"},
+			{"type":"directory","oid":"b","size":0,"path":"Q8_0"},
+			{"type":"file","oid":"c","size":10,"path":"Q8_0/model-Q8_0.gguf"},
+			{"type":"file","oid":"d","size":10,"path":"model-Q4_K_M.GGUF"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewHubClient("", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	files, err := client.ListGGUFFiles(context.Background(), "org/model-GGUF", "")
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "Q8_0/model-Q8_0.gguf", files[0].Path)
+	assert.Equal(t, "model-Q4_K_M.GGUF", files[1].Path)
+}
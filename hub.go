@@ -0,0 +1,309 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// HubClientOptions represents options for the HubClient.
+type HubClientOptions struct {
+	// (Default: HF_ENDPOINT or https://huggingface.co). The endpoint of the Hub.
+	Endpoint string
+
+	// (Default: DefaultCacheDir()). The directory of the local cache of downloaded files.
+	CacheDir string
+
+	// (Default: HF_HUB_OFFLINE). Whether files are resolved from the local cache only.
+	Offline bool
+
+	HTTPClient HTTPClient
+}
+
+// HubClient is a client for the API of the Hugging Face Hub.
+type HubClient struct {
+	serverClient
+	opts HubClientOptions
+}
+
+// NewHubClient creates a new HubClient instance with the specified token.
+// The token is optional for public repositories.
+func NewHubClient(token string, optFns ...func(o *HubClientOptions)) *HubClient {
+	opts := HubClientOptions{
+		Endpoint: os.Getenv("HF_ENDPOINT"),
+		CacheDir: DefaultCacheDir(),
+		Offline:  isOfflineEnv(),
+	}
+
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://huggingface.co"
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &HubClient{
+		serverClient: newServerClient(opts.Endpoint, token, opts.HTTPClient),
+		opts:         opts,
+	}
+}
+
+// GatedStatus specifies whether access to a repository requires approval.
+type GatedStatus string
+
+const (
+	// GatedStatusNone means that the repository is not gated.
+	GatedStatusNone GatedStatus = ""
+
+	// GatedStatusAuto means that access requests are approved automatically.
+	GatedStatusAuto GatedStatus = "auto"
+
+	// GatedStatusManual means that access requests are approved manually by the authors.
+	GatedStatusManual GatedStatus = "manual"
+)
+
+// UnmarshalJSON decodes the gated status, which is false for repositories that are not gated.
+func (gs *GatedStatus) UnmarshalJSON(data []byte) error {
+	var gated bool
+	if err := json.Unmarshal(data, &gated); err == nil {
+		if gated {
+			// Older repositories report true for automatically approved access requests
+			*gs = GatedStatusAuto
+		} else {
+			*gs = GatedStatusNone
+		}
+
+		return nil
+	}
+
+	var status string
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("invalid gated status %s", data)
+	}
+
+	*gs = GatedStatus(status)
+
+	return nil
+}
+
+// BlobLFSInfo contains the LFS metadata of a file.
+type BlobLFSInfo struct {
+	// The size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// The sha256 checksum of the file.
+	SHA256 string `json:"sha256"`
+
+	// The size of the LFS pointer file in bytes.
+	PointerSize int64 `json:"pointerSize"`
+}
+
+// RepoSibling represents a file of a repository.
+type RepoSibling struct {
+	// The path of the file relative to the root of the repository.
+	RFilename string `json:"rfilename"`
+
+	// The size of the file in bytes (only set if requested with blobs).
+	Size *int64 `json:"size,omitempty"`
+
+	// The git blob id of the file (only set if requested with blobs).
+	BlobID string `json:"blobId,omitempty"`
+
+	// The LFS metadata of the file, if the file is stored with LFS.
+	LFS *BlobLFSInfo `json:"lfs,omitempty"`
+}
+
+// SafetensorsInfo contains the parameter counts of the safetensors weights of a model.
+type SafetensorsInfo struct {
+	// The number of parameters for each data type, e.g. F16 or BF16.
+	Parameters map[string]int64 `json:"parameters"`
+
+	// The total number of parameters.
+	Total int64 `json:"total"`
+}
+
+// ModelInfo contains the metadata of a model repository.
+type ModelInfo struct {
+	// The id of the model, e.g. openai-community/gpt2.
+	ID string `json:"id"`
+
+	// The author of the model.
+	Author string `json:"author,omitempty"`
+
+	// The commit sha of the revision.
+	SHA string `json:"sha,omitempty"`
+
+	// The time of the creation of the repository.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// The time of the last commit.
+	LastModified *time.Time `json:"lastModified,omitempty"`
+
+	// Whether the repository is private.
+	Private bool `json:"private"`
+
+	// Whether the repository is disabled.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Whether access to the repository requires approval.
+	Gated GatedStatus `json:"gated,omitempty"`
+
+	// The number of downloads over the last 30 days.
+	Downloads int64 `json:"downloads"`
+
+	// The number of likes.
+	Likes int64 `json:"likes"`
+
+	// The library of the model, e.g. transformers.
+	LibraryName string `json:"library_name,omitempty"`
+
+	// The tags of the model.
+	Tags []string `json:"tags,omitempty"`
+
+	// The task of the model, e.g. text-classification.
+	PipelineTag string `json:"pipeline_tag,omitempty"`
+
+	// The metadata of the model card.
+	CardData map[string]any `json:"cardData,omitempty"`
+
+	// The files of the repository.
+	Siblings []RepoSibling `json:"siblings,omitempty"`
+
+	// The parameter counts of the safetensors weights, if the model has safetensors weights.
+	Safetensors *SafetensorsInfo `json:"safetensors,omitempty"`
+}
+
+// Used with ListModels
+type ListModelsRequest struct {
+	// Only models whose id contains the search string.
+	Search string
+
+	// Only models of the author or organization.
+	Author string
+
+	// Only models for the task, e.g. text-classification.
+	PipelineTag string
+
+	// Only models of the library, e.g. transformers.
+	Library string
+
+	// Only models of the language, e.g. en.
+	Language string
+
+	// Only models with all of the tags.
+	Tags []string
+
+	// The property to sort by, e.g. downloads, likes or lastModified.
+	Sort string
+
+	// (Default: false). Whether the models are sorted in ascending instead of descending order.
+	Ascending bool
+
+	// (Default: 0). The maximum number of models. Zero lists all models.
+	Limit int
+
+	// (Default: false). Whether all metadata including the files of each model is returned.
+	Full bool
+
+	// (Default: false). Whether the metadata of the model card is returned.
+	CardData bool
+}
+
+// ListModels lists the models of the Hub matching the request.
+// The models are fetched page by page while iterating.
+func (hc *HubClient) ListModels(ctx context.Context, req *ListModelsRequest) *Iterator[ModelInfo] {
+	query := listQuery(req.Search, req.Author, req.Tags, req.Sort, req.Ascending, req.Limit, req.Full)
+
+	if req.PipelineTag != "" {
+		query.Set("pipeline_tag", req.PipelineTag)
+	}
+
+	if req.Library != "" {
+		query.Set("library", req.Library)
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.CardData {
+		query.Set("cardData", "true")
+	}
+
+	u := fmt.Sprintf("%s/api/models?%s", hc.endpoint, query.Encode())
+
+	return newIterator(ctx, u, req.Limit, fetchJSONPage[ModelInfo](&hc.transport))
+}
+
+// listQuery returns the query parameters shared by the listings of repositories.
+func listQuery(search, author string, tags []string, sortBy string, ascending bool, limit int, full bool) url.Values {
+	query := url.Values{}
+
+	if search != "" {
+		query.Set("search", search)
+	}
+
+	if author != "" {
+		query.Set("author", author)
+	}
+
+	for _, tag := range tags {
+		query.Add("filter", tag)
+	}
+
+	if sortBy != "" {
+		query.Set("sort", sortBy)
+
+		if ascending {
+			query.Set("direction", "1")
+		} else {
+			query.Set("direction", "-1")
+		}
+	}
+
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	if full {
+		query.Set("full", "true")
+	}
+
+	return query
+}
+
+// ModelInfo returns the metadata of the model at the specified revision, including the sizes and
+// LFS metadata of its files. The revision defaults to the main branch if empty.
+func (hc *HubClient) ModelInfo(ctx context.Context, repoID, revision string) (*ModelInfo, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	path := fmt.Sprintf("/api/models/%s", repoID)
+	if revision != "" {
+		path = fmt.Sprintf("%s/revision/%s", path, url.PathEscape(revision))
+	}
+
+	body, err := hc.get(ctx, path+"?blobs=true")
+	if err != nil {
+		return nil, err
+	}
+
+	modelInfo := ModelInfo{}
+	if err := json.Unmarshal(body, &modelInfo); err != nil {
+		return nil, err
+	}
+
+	return &modelInfo, nil
+}
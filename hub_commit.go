@@ -0,0 +1,388 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// CommitOperation represents a change of a commit.
+// Use AddFileFromBytes, AddFileFromPath, DeleteFile or DeleteFolder to create one.
+type CommitOperation interface {
+	// path returns the path in the repository the operation applies to.
+	path() string
+}
+
+// AddFileFromBytes creates an operation that adds or updates the file at the path in the repository with the data.
+func AddFileFromBytes(pathInRepo string, data []byte) CommitOperation {
+	return &addFileOperation{pathInRepo: pathInRepo, data: data}
+}
+
+// AddFileFromPath creates an operation that adds or updates the file at the path in the repository with the content
+// of the local file. The file is read when the commit is created and must not change until then.
+func AddFileFromPath(pathInRepo, localPath string) CommitOperation {
+	return &addFileOperation{pathInRepo: pathInRepo, localPath: localPath}
+}
+
+// DeleteFile creates an operation that deletes the file at the path in the repository.
+func DeleteFile(pathInRepo string) CommitOperation {
+	return deleteOperation{pathInRepo: pathInRepo}
+}
+
+// DeleteFolder creates an operation that deletes the folder at the path in the repository with all its files.
+func DeleteFolder(pathInRepo string) CommitOperation {
+	return deleteOperation{pathInRepo: strings.TrimSuffix(pathInRepo, "/"), folder: true}
+}
+
+type addFileOperation struct {
+	pathInRepo string
+	data       []byte
+	localPath  string
+
+	// Set while the commit is created
+	size       int64
+	sha256     string
+	uploadMode string
+	ignore     bool
+}
+
+func (op *addFileOperation) path() string {
+	return op.pathInRepo
+}
+
+// open returns a reader of the content of the file.
+func (op *addFileOperation) open() (io.ReadSeekCloser, error) {
+	if op.localPath != "" {
+		return os.Open(op.localPath)
+	}
+
+	return nopSeekCloser{bytes.NewReader(op.data)}, nil
+}
+
+// readAll returns the content of the file.
+func (op *addFileOperation) readAll() ([]byte, error) {
+	if op.localPath != "" {
+		return os.ReadFile(op.localPath)
+	}
+
+	return op.data, nil
+}
+
+// inspect determines the size, the sha256 checksum and a sample of the first 512 bytes of the content.
+func (op *addFileOperation) inspect() ([]byte, error) {
+	r, err := op.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	sample := &bytes.Buffer{}
+	h := sha256.New()
+
+	n, err := io.Copy(io.MultiWriter(h, &limitedWriter{w: sample, n: 512}), r)
+	if err != nil {
+		return nil, err
+	}
+
+	op.size = n
+	op.sha256 = hex.EncodeToString(h.Sum(nil))
+
+	return sample.Bytes(), nil
+}
+
+type deleteOperation struct {
+	pathInRepo string
+	folder     bool
+}
+
+func (op deleteOperation) path() string {
+	return op.pathInRepo
+}
+
+// Request structure for CreateCommit
+type CreateCommitRequest struct {
+	// (Required) The id of the repository, e.g. org/name.
+	RepoID string
+
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+
+	// (Default: main). The branch the commit is created on.
+	Revision string
+
+	// (Required) The changes of the commit.
+	Operations []CommitOperation
+
+	// (Required) The summary of the commit.
+	CommitMessage string
+
+	// The description of the commit.
+	CommitDescription string
+
+	// The commit hash the commit is expected to be based on. The commit fails if the branch
+	// has moved on since, which avoids overwriting concurrent changes.
+	ParentCommit string
+
+	// (Default: false). Whether a pull request with the commit is opened instead of committing to the branch.
+	CreatePR bool
+}
+
+// Response structure for CreateCommit
+type CreateCommitResponse struct {
+	// The URL of the commit.
+	CommitURL string `json:"commitUrl"`
+
+	// The hash of the commit.
+	CommitOID string `json:"commitOid"`
+
+	// The URL of the pull request, if one is opened.
+	PullRequestURL string `json:"pullRequestUrl,omitempty"`
+}
+
+// CreateCommit creates a commit with the operations in a repository of the Hub. Files that are stored
+// with LFS, as determined by the Hub, are uploaded to the LFS storage before the commit is created.
+func (hc *HubClient) CreateCommit(ctx context.Context, req *CreateCommitRequest) (*CreateCommitResponse, error) {
+	if req.RepoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	if req.CommitMessage == "" {
+		return nil, errors.New("commitMessage is required")
+	}
+
+	if len(req.Operations) == 0 {
+		return nil, errors.New("operations are required")
+	}
+
+	revision := req.Revision
+	if revision == "" {
+		revision = "main"
+	}
+
+	adds := []*addFileOperation{}
+
+	for _, op := range req.Operations {
+		if op == nil || op.path() == "" {
+			return nil, errors.New("operations require a path")
+		}
+
+		if add, ok := op.(*addFileOperation); ok {
+			adds = append(adds, add)
+		}
+	}
+
+	if err := hc.preupload(ctx, req, revision, adds); err != nil {
+		return nil, err
+	}
+
+	lfsFiles := []*addFileOperation{}
+
+	for _, add := range adds {
+		if add.uploadMode == "lfs" && !add.ignore {
+			lfsFiles = append(lfsFiles, add)
+		}
+	}
+
+	if err := hc.uploadLFSFiles(ctx, req.RepoType, req.RepoID, revision, lfsFiles); err != nil {
+		return nil, err
+	}
+
+	body, err := commitPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/commit/%s", hc.repoAPIPath(req.RepoType, req.RepoID), url.PathEscape(revision))
+	if req.CreatePR {
+		path += "?create_pr=1"
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, hc.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-ndjson")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resBody, err := hc.do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	createCommitResponse := CreateCommitResponse{}
+	if err := json.Unmarshal(resBody, &createCommitResponse); err != nil {
+		return nil, err
+	}
+
+	return &createCommitResponse, nil
+}
+
+// preupload inspects the files and asks the Hub whether they are uploaded as regular files or with LFS.
+func (hc *HubClient) preupload(ctx context.Context, req *CreateCommitRequest, revision string, adds []*addFileOperation) error {
+	const batchSize = 256
+
+	for start := 0; start < len(adds); start += batchSize {
+		end := start + batchSize
+		if end > len(adds) {
+			end = len(adds)
+		}
+
+		type preuploadFile struct {
+			Path   string `json:"path"`
+			Sample string `json:"sample"`
+			Size   int64  `json:"size"`
+		}
+
+		files := []preuploadFile{}
+
+		for _, add := range adds[start:end] {
+			sample, err := add.inspect()
+			if err != nil {
+				return fmt.Errorf("%s: %w", add.pathInRepo, err)
+			}
+
+			files = append(files, preuploadFile{
+				Path:   add.pathInRepo,
+				Sample: base64.StdEncoding.EncodeToString(sample),
+				Size:   add.size,
+			})
+		}
+
+		path := fmt.Sprintf("%s/preupload/%s", hc.repoAPIPath(req.RepoType, req.RepoID), url.PathEscape(revision))
+		if req.CreatePR {
+			path += "?create_pr=1"
+		}
+
+		body, err := hc.post(ctx, path, map[string]any{"files": files})
+		if err != nil {
+			return err
+		}
+
+		res := struct {
+			Files []struct {
+				Path         string `json:"path"`
+				UploadMode   string `json:"uploadMode"`
+				ShouldIgnore bool   `json:"shouldIgnore"`
+			} `json:"files"`
+		}{}
+
+		if err := json.Unmarshal(body, &res); err != nil {
+			return err
+		}
+
+		for _, file := range res.Files {
+			for _, add := range adds[start:end] {
+				if add.pathInRepo == file.Path {
+					add.uploadMode = file.UploadMode
+					add.ignore = file.ShouldIgnore
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// commitPayload returns the newline delimited JSON payload of the commit endpoint.
+func commitPayload(req *CreateCommitRequest) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+
+	type line struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+
+	header := map[string]string{
+		"summary":     req.CommitMessage,
+		"description": req.CommitDescription,
+	}
+
+	if req.ParentCommit != "" {
+		header["parentCommit"] = req.ParentCommit
+	}
+
+	if err := enc.Encode(line{Key: "header", Value: header}); err != nil {
+		return nil, err
+	}
+
+	for _, op := range req.Operations {
+		var l line
+
+		switch op := op.(type) {
+		case *addFileOperation:
+			if op.ignore {
+				continue
+			}
+
+			if op.uploadMode == "lfs" {
+				l = line{Key: "lfsFile", Value: map[string]any{"path": op.pathInRepo, "algo": "sha256", "oid": op.sha256}}
+				break
+			}
+
+			content, err := op.readAll()
+			if err != nil {
+				return nil, err
+			}
+
+			l = line{Key: "file", Value: map[string]string{"path": op.pathInRepo, "content": base64.StdEncoding.EncodeToString(content), "encoding": "base64"}}
+		case deleteOperation:
+			key := "deletedFile"
+			if op.folder {
+				key = "deletedFolder"
+			}
+
+			l = line{Key: key, Value: map[string]string{"path": op.pathInRepo}}
+		}
+
+		if err := enc.Encode(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// nopSeekCloser adds a no-op Close method to a ReadSeeker.
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
+
+// limitedWriter writes at most n bytes to the underlying writer and discards the rest.
+type limitedWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.n > 0 {
+		chunk := p
+		if int64(len(chunk)) > lw.n {
+			chunk = chunk[:lw.n]
+		}
+
+		n, err := lw.w.Write(chunk)
+		lw.n -= int64(n)
+
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return len(p), nil
+}
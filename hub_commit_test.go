@@ -0,0 +1,240 @@
+package huggingface
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCommit(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		server   *httptest.Server
+		stored   = map[string]string{}
+		parts    = map[int]string{}
+		verified = []string{}
+		commit   = []map[string]any{}
+	)
+
+	big := strings.Repeat("x", 10)
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/models/org/model/preupload/main":
+			assert.Equal(t, "1", r.URL.Query().Get("create_pr"))
+
+			payload := struct {
+				Files []struct {
+					Path   string `json:"path"`
+					Sample string `json:"sample"`
+					Size   int64  `json:"size"`
+				} `json:"files"`
+			}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+			files := []map[string]any{}
+
+			for _, file := range payload.Files {
+				sample, _ := base64.StdEncoding.DecodeString(file.Sample)
+				assert.LessOrEqual(t, len(sample), 512)
+
+				mode := "regular"
+				if strings.HasSuffix(file.Path, ".bin") {
+					mode = "lfs"
+				}
+
+				files = append(files, map[string]any{"path": file.Path, "uploadMode": mode, "shouldIgnore": file.Path == ".DS_Store"})
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.URL.Path == "/org/model.git/info/lfs/objects/batch":
+			assert.Equal(t, "application/vnd.git-lfs+json", r.Header.Get("Content-Type"))
+
+			payload := struct {
+				Objects []lfsObject `json:"objects"`
+			}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Len(t, payload.Objects, 2)
+
+			objects := []map[string]any{}
+			transfer := "basic"
+
+			for _, object := range payload.Objects {
+				actions := map[string]any{
+					"verify": map[string]any{"href": server.URL + "/verify"},
+				}
+
+				switch object.OID {
+				case sha256Hex("weights"):
+					actions["upload"] = map[string]any{"href": server.URL + "/storage/" + object.OID}
+				case sha256Hex(big):
+					// The storage requests a multipart upload in parts of 4 bytes
+					transfer = "multipart"
+					actions["upload"] = map[string]any{"href": server.URL + "/complete", "header": map[string]string{
+						"chunk_size": "4",
+						"1":          server.URL + "/part/1",
+						"2":          server.URL + "/part/2",
+						"3":          server.URL + "/part/3",
+					}}
+				}
+
+				objects = append(objects, map[string]any{"oid": object.OID, "size": object.Size, "actions": actions})
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{"transfer": transfer, "objects": objects})
+		case strings.HasPrefix(r.URL.Path, "/storage/"), strings.HasPrefix(r.URL.Path, "/part/"):
+			assert.Empty(t, r.Header.Get("Authorization"))
+
+			data, _ := io.ReadAll(r.Body)
+
+			if oid, ok := strings.CutPrefix(r.URL.Path, "/storage/"); ok {
+				stored[oid] = string(data)
+			} else {
+				var n int
+				_, _ = fmt.Sscanf(r.URL.Path, "/part/%d", &n)
+				parts[n] = string(data)
+				w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, n))
+			}
+		case r.URL.Path == "/complete":
+			payload := struct {
+				OID   string `json:"oid"`
+				Parts []struct {
+					PartNumber int    `json:"partNumber"`
+					ETag       string `json:"etag"`
+				} `json:"parts"`
+			}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Len(t, payload.Parts, 3)
+			assert.Equal(t, "etag-3", payload.Parts[2].ETag)
+
+			stored[payload.OID] = parts[1] + parts[2] + parts[3]
+		case r.URL.Path == "/verify":
+			assert.Equal(t, "Bearer your-token", r.Header.Get("Authorization"))
+
+			payload := lfsObject{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			verified = append(verified, payload.OID)
+		case r.URL.Path == "/api/models/org/model/commit/main":
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			assert.Equal(t, "1", r.URL.Query().Get("create_pr"))
+
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				line := map[string]any{}
+				assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+				commit = append(commit, line)
+			}
+
+			_, _ = w.Write([]byte(`{"commitUrl":"https://hf.co/org/model/commit/abc","commitOid":"abc","pullRequestUrl":"https://hf.co/org/model/discussions/1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	local := filepath.Join(t.TempDir(), "big.bin")
+	assert.NoError(t, os.WriteFile(local, []byte(big), 0o600))
+
+	res, err := client.CreateCommit(context.Background(), &CreateCommitRequest{
+		RepoID: "org/model",
+		Operations: []CommitOperation{
+			AddFileFromBytes("config.json", []byte(`{"model_type":"bert"}`)),
+			AddFileFromBytes("weights.bin", []byte("weights")),
+			AddFileFromPath("checkpoints/big.bin", local),
+			AddFileFromBytes(".DS_Store", []byte("ignored")),
+			DeleteFile("old.bin"),
+			DeleteFolder("logs/"),
+		},
+		CommitMessage: "Upload model",
+		ParentCommit:  "parent",
+		CreatePR:      true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", res.CommitOID)
+	assert.Equal(t, "https://hf.co/org/model/discussions/1", res.PullRequestURL)
+
+	assert.Equal(t, "weights", stored[sha256Hex("weights")])
+	assert.Equal(t, big, stored[sha256Hex(big)])
+	assert.ElementsMatch(t, []string{sha256Hex("weights"), sha256Hex(big)}, verified)
+
+	assert.Equal(t, []map[string]any{
+		{"key": "header", "value": map[string]any{"summary": "Upload model", "description": "", "parentCommit": "parent"}},
+		{"key": "file", "value": map[string]any{"path": "config.json", "content": base64.StdEncoding.EncodeToString([]byte(`{"model_type":"bert"}`)), "encoding": "base64"}},
+		{"key": "lfsFile", "value": map[string]any{"path": "weights.bin", "algo": "sha256", "oid": sha256Hex("weights")}},
+		{"key": "lfsFile", "value": map[string]any{"path": "checkpoints/big.bin", "algo": "sha256", "oid": sha256Hex(big)}},
+		{"key": "deletedFile", "value": map[string]any{"path": "old.bin"}},
+		{"key": "deletedFolder", "value": map[string]any{"path": "logs"}},
+	}, commit)
+
+	t.Run("Missing commit message", func(t *testing.T) {
+		_, err := client.CreateCommit(context.Background(), &CreateCommitRequest{
+			RepoID:     "org/model",
+			Operations: []CommitOperation{DeleteFile("old.bin")},
+		})
+		assert.EqualError(t, err, "commitMessage is required")
+	})
+}
+
+func TestCreateRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/repos/create":
+			payload := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]any{"name": "data", "organization": "org", "private": true, "type": "dataset"}, payload)
+
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"You already created this dataset repo"}`))
+		case "/api/repos/delete":
+			assert.Equal(t, http.MethodDelete, r.Method)
+
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/datasets/org/data/settings":
+			assert.Equal(t, http.MethodPut, r.Method)
+
+			payload := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]any{"private": false}, payload)
+		}
+	}))
+	defer server.Close()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	req := &CreateRepoRequest{RepoID: "org/data", RepoType: RepoTypeDataset, Private: true}
+
+	_, err := client.CreateRepo(context.Background(), req)
+	assert.EqualError(t, err, "huggingfaces error: You already created this dataset repo")
+
+	req.ExistOK = true
+
+	res, err := client.CreateRepo(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/datasets/org/data", res.URL)
+
+	assert.Error(t, client.DeleteRepo(context.Background(), &DeleteRepoRequest{RepoID: "org/data", RepoType: RepoTypeDataset}))
+	assert.NoError(t, client.DeleteRepo(context.Background(), &DeleteRepoRequest{RepoID: "org/data", RepoType: RepoTypeDataset, MissingOK: true}))
+
+	assert.NoError(t, client.UpdateRepoVisibility(context.Background(), &UpdateRepoVisibilityRequest{RepoID: "org/data", RepoType: RepoTypeDataset}))
+}
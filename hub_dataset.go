@@ -0,0 +1,186 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DatasetInfo contains the metadata of a dataset repository.
+type DatasetInfo struct {
+	// The id of the dataset, e.g. stanfordnlp/imdb.
+	ID string `json:"id"`
+
+	// The author of the dataset.
+	Author string `json:"author,omitempty"`
+
+	// The commit sha of the revision.
+	SHA string `json:"sha,omitempty"`
+
+	// The time of the creation of the repository.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// The time of the last commit.
+	LastModified *time.Time `json:"lastModified,omitempty"`
+
+	// Whether the repository is private.
+	Private bool `json:"private"`
+
+	// Whether the repository is disabled.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Whether access to the repository requires approval.
+	Gated GatedStatus `json:"gated,omitempty"`
+
+	// The number of downloads over the last 30 days.
+	Downloads int64 `json:"downloads"`
+
+	// The number of likes.
+	Likes int64 `json:"likes"`
+
+	// The tags of the dataset.
+	Tags []string `json:"tags,omitempty"`
+
+	// The description of the dataset.
+	Description string `json:"description,omitempty"`
+
+	// The metadata of the dataset card.
+	CardData map[string]any `json:"cardData,omitempty"`
+
+	// The files of the repository.
+	Siblings []RepoSibling `json:"siblings,omitempty"`
+}
+
+// Used with ListDatasets
+type ListDatasetsRequest struct {
+	// Only datasets whose id contains the search string.
+	Search string
+
+	// Only datasets of the author or organization.
+	Author string
+
+	// Only datasets with all of the tags, e.g. task_categories:text-classification or language:en.
+	Tags []string
+
+	// The property to sort by, e.g. downloads, likes or lastModified.
+	Sort string
+
+	// (Default: false). Whether the datasets are sorted in ascending instead of descending order.
+	Ascending bool
+
+	// (Default: 0). The maximum number of datasets. Zero lists all datasets.
+	Limit int
+
+	// (Default: false). Whether all metadata including the files of each dataset is returned.
+	Full bool
+}
+
+// ListDatasets lists the datasets of the Hub matching the request.
+// The datasets are fetched page by page while iterating.
+func (hc *HubClient) ListDatasets(ctx context.Context, req *ListDatasetsRequest) *Iterator[DatasetInfo] {
+	query := listQuery(req.Search, req.Author, req.Tags, req.Sort, req.Ascending, req.Limit, req.Full)
+
+	u := fmt.Sprintf("%s/api/datasets?%s", hc.endpoint, query.Encode())
+
+	return newIterator(ctx, u, req.Limit, fetchJSONPage[DatasetInfo](&hc.transport))
+}
+
+// DatasetInfo returns the metadata of the dataset at the specified revision, including the sizes and
+// LFS metadata of its files. The revision defaults to the main branch if empty.
+func (hc *HubClient) DatasetInfo(ctx context.Context, repoID, revision string) (*DatasetInfo, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	path := fmt.Sprintf("/api/datasets/%s", repoID)
+	if revision != "" {
+		path = fmt.Sprintf("%s/revision/%s", path, url.PathEscape(revision))
+	}
+
+	body, err := hc.get(ctx, path+"?blobs=true")
+	if err != nil {
+		return nil, err
+	}
+
+	datasetInfo := DatasetInfo{}
+	if err := json.Unmarshal(body, &datasetInfo); err != nil {
+		return nil, err
+	}
+
+	return &datasetInfo, nil
+}
+
+// RepoFile represents a file or directory of a repository.
+type RepoFile struct {
+	// Either file or directory.
+	Type string `json:"type"`
+
+	// The path of the file relative to the root of the repository.
+	Path string `json:"path"`
+
+	// The size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// The git object id of the file.
+	OID string `json:"oid"`
+
+	// The LFS metadata of the file, if the file is stored with LFS.
+	LFS *RepoFileLFSInfo `json:"lfs,omitempty"`
+}
+
+// RepoFileLFSInfo contains the LFS metadata of a file listed with ListRepoFiles.
+type RepoFileLFSInfo struct {
+	// The sha256 checksum of the file.
+	OID string `json:"oid"`
+
+	// The size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// The size of the LFS pointer file in bytes.
+	PointerSize int64 `json:"pointerSize"`
+}
+
+// Used with ListRepoFiles
+type ListRepoFilesRequest struct {
+	// (Required) The id of the repository, e.g. org/name.
+	RepoID string
+
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+
+	// (Default: main). The branch, tag or commit hash.
+	Revision string
+
+	// The directory to list. The root of the repository is listed if empty.
+	Path string
+
+	// (Default: false). Whether the files of subdirectories are listed.
+	Recursive bool
+}
+
+// ListRepoFiles lists the files and directories of a repository.
+// The files are fetched page by page while iterating.
+func (hc *HubClient) ListRepoFiles(ctx context.Context, req *ListRepoFilesRequest) *Iterator[RepoFile] {
+	if req.RepoID == "" {
+		return errIterator[RepoFile](errors.New("repoID is required"))
+	}
+
+	revision := req.Revision
+	if revision == "" {
+		revision = "main"
+	}
+
+	u := fmt.Sprintf("%s%s/tree/%s", hc.endpoint, hc.repoAPIPath(req.RepoType, req.RepoID), url.PathEscape(revision))
+	if req.Path != "" {
+		u = fmt.Sprintf("%s/%s", u, escapePath(req.Path))
+	}
+
+	if req.Recursive {
+		u += "?recursive=true"
+	}
+
+	return newIterator(ctx, u, 0, fetchJSONPage[RepoFile](&hc.transport))
+}
@@ -0,0 +1,366 @@
+package huggingface
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // git blob ids are sha1 checksums
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DownloadFileOptions represents options for DownloadFile.
+type DownloadFileOptions struct {
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+
+	// (Default: false). Whether the file is downloaded even if it is already cached.
+	ForceDownload bool
+}
+
+// fileMetadata represents the metadata of a file of the Hub.
+type fileMetadata struct {
+	// The commit the revision resolves to.
+	commit string
+
+	// The etag of the file, which is the sha256 checksum for LFS files and the git blob id otherwise.
+	etag string
+
+	// The size of the file in bytes, or -1 if unknown.
+	size int64
+
+	// The URL the file is downloaded from.
+	location string
+}
+
+// DownloadFile downloads a file of a repository into the local cache and returns the path of the cached file.
+// The cache uses the same layout as the huggingface_hub Python library and can be shared with it.
+// The revision is a branch, tag or commit hash and defaults to main if empty. Files that are already cached
+// are not downloaded again. In offline mode, or if the Hub is not reachable, the file is resolved from the
+// cache only and ErrNotCached is returned if it is missing.
+func (hc *HubClient) DownloadFile(ctx context.Context, repoID, filename, revision string, optFns ...func(o *DownloadFileOptions)) (string, error) {
+	opts := DownloadFileOptions{
+		RepoType: RepoTypeModel,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if revision == "" {
+		revision = "main"
+	}
+
+	if filename == "" || !filepath.IsLocal(filepath.FromSlash(filename)) {
+		return "", fmt.Errorf("invalid filename %q", filename)
+	}
+
+	if err := checkRevision(revision); err != nil {
+		return "", err
+	}
+
+	rc, err := newRepoCache(hc.opts.CacheDir, opts.RepoType, repoID)
+	if err != nil {
+		return "", err
+	}
+
+	if hc.opts.Offline {
+		return rc.cachedFile(revision, filename)
+	}
+
+	metadata, err := hc.fileMetadata(ctx, rc.repoType, repoID, filename, revision)
+	if err != nil {
+		// Falls back to the cache if the Hub is not reachable
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) && ctx.Err() == nil {
+			if path, cacheErr := rc.cachedFile(revision, filename); cacheErr == nil {
+				return path, nil
+			}
+		}
+
+		return "", err
+	}
+
+	return hc.downloadToCache(ctx, rc, filename, revision, metadata, opts.ForceDownload, blobTransfer{})
+}
+
+// downloadToCache downloads the file described by the metadata into the cache, unless it is already cached.
+func (hc *HubClient) downloadToCache(ctx context.Context, rc *repoCache, filename, revision string, metadata *fileMetadata, force bool, transfer blobTransfer) (string, error) {
+	// The commit and the etag are part of the paths within the cache
+	if !commitHashRegexp.MatchString(metadata.commit) {
+		return "", fmt.Errorf("invalid commit hash %q in response of the hub", metadata.commit)
+	}
+
+	if !etagRegexp.MatchString(metadata.etag) {
+		return "", fmt.Errorf("invalid etag %q in response of the hub", metadata.etag)
+	}
+
+	if err := rc.writeRef(revision, metadata.commit); err != nil {
+		return "", err
+	}
+
+	if !force {
+		if _, err := os.Stat(rc.snapshotPath(metadata.commit, filename)); err == nil {
+			reportProgress(transfer.progress, metadata.size)
+			return rc.snapshotPath(metadata.commit, filename), nil
+		}
+	}
+
+	blob := rc.blobPath(metadata.etag)
+
+	// Concurrent downloaders of the same blob wait for each other
+	unlock, err := lockFile(ctx, rc.lockPath(metadata.etag))
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(blob); err != nil || force {
+		if err := hc.downloadBlob(ctx, metadata, blob, transfer); err != nil {
+			return "", err
+		}
+	} else {
+		reportProgress(transfer.progress, metadata.size)
+	}
+
+	return rc.linkSnapshot(metadata.commit, filename, metadata.etag)
+}
+
+// fileMetadata fetches the metadata of a file with a HEAD request to its resolve URL. Redirects to
+// the storage of LFS files are not followed, since the metadata is part of the redirect response.
+func (hc *HubClient) fileMetadata(ctx context.Context, repoType RepoType, repoID, filename, revision string) (*fileMetadata, error) {
+	// Other HTTP clients may follow the redirects to the storage, whose responses lack the metadata
+	if _, ok := hc.httpClient.(*http.Client); !ok {
+		return hc.listedFileMetadata(ctx, repoType, repoID, filename, revision)
+	}
+
+	location := hc.resolveFileURL(repoType, repoID, filename, revision)
+
+	for redirects := 0; ; redirects++ {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodHead, location, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Compressed responses would report the size of the compressed file
+		httpReq.Header.Set("Accept-Encoding", "identity")
+
+		res, err := hc.sendWithoutRedirect(httpReq)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Body.Close()
+
+		if res.StatusCode >= 300 && res.StatusCode <= 399 {
+			target, err := res.Location()
+			if err != nil {
+				return nil, err
+			}
+
+			// Relative redirects point to a renamed repository and are followed, absolute
+			// redirects point to the storage of LFS files
+			if !strings.HasPrefix(res.Header.Get("Location"), "/") || redirects >= 10 {
+				return newFileMetadata(res, target.String())
+			}
+
+			location = target.String()
+
+			continue
+		}
+
+		return newFileMetadata(res, location)
+	}
+}
+
+// listedFileMetadata takes the metadata of a file from the listing of the files of the repository.
+func (hc *HubClient) listedFileMetadata(ctx context.Context, repoType RepoType, repoID, filename, revision string) (*fileMetadata, error) {
+	commit, siblings, err := hc.repoFiles(ctx, repoType, repoID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sibling := range siblings {
+		if sibling.RFilename != filename {
+			continue
+		}
+
+		metadata := &fileMetadata{
+			commit:   commit,
+			etag:     sibling.BlobID,
+			size:     siblingSize(sibling),
+			location: hc.resolveFileURL(repoType, repoID, filename, commit),
+		}
+
+		if sibling.LFS != nil {
+			metadata.etag = sibling.LFS.SHA256
+		}
+
+		return metadata, nil
+	}
+
+	return nil, &HTTPError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("file %s not found in revision %s", filename, revision)}
+}
+
+// newFileMetadata creates the metadata of a file from the response of a HEAD request.
+func newFileMetadata(res *http.Response, location string) (*fileMetadata, error) {
+	commit := res.Header.Get("X-Repo-Commit")
+	if commit == "" {
+		return nil, errors.New("missing commit hash in response of the hub")
+	}
+
+	etag := res.Header.Get("X-Linked-Etag")
+	if etag == "" {
+		etag = res.Header.Get("ETag")
+	}
+
+	etag = normalizeETag(etag)
+	if etag == "" {
+		return nil, errors.New("missing etag in response of the hub")
+	}
+
+	size := int64(-1)
+
+	sizeHeader := res.Header.Get("X-Linked-Size")
+	if sizeHeader == "" {
+		sizeHeader = res.Header.Get("Content-Length")
+	}
+
+	if sizeHeader != "" {
+		if n, err := strconv.ParseInt(sizeHeader, 10, 64); err == nil {
+			size = n
+		}
+	}
+
+	return &fileMetadata{
+		commit:   commit,
+		etag:     etag,
+		size:     size,
+		location: location,
+	}, nil
+}
+
+// sendWithoutRedirect sends the request without following redirects. Redirect responses are returned
+// instead of an error. Redirects are only disabled if the HTTP client of the options is an *http.Client,
+// see fileMetadata for other clients.
+func (hc *HubClient) sendWithoutRedirect(req *http.Request) (*http.Response, error) {
+	httpClient := hc.httpClient
+
+	if c, ok := httpClient.(*http.Client); ok {
+		noRedirect := *c
+		noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+
+		httpClient = &noRedirect
+	}
+
+	if hc.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", hc.token))
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 300 && res.StatusCode <= 399 {
+		return res, nil
+	}
+
+	return checkResponse(res)
+}
+
+// transportFor returns the transport used for requests to the URL. The token is only sent to the Hub
+// and not to the storage LFS files are redirected to.
+func (hc *HubClient) transportFor(rawURL string) *transport {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return &hc.transport
+	}
+
+	endpoint, err := url.Parse(hc.endpoint)
+	if err != nil || u.Host == endpoint.Host {
+		return &hc.transport
+	}
+
+	return &transport{httpClient: hc.httpClient}
+}
+
+// resolveFileURL returns the URL to download the file at the revision.
+func (hc *HubClient) resolveFileURL(repoType RepoType, repoID, filename, revision string) string {
+	prefix := ""
+
+	switch repoType {
+	case RepoTypeDataset:
+		prefix = "datasets/"
+	case RepoTypeSpace:
+		prefix = "spaces/"
+	}
+
+	return fmt.Sprintf("%s/%s%s/resolve/%s/%s", hc.endpoint, prefix, repoID, url.PathEscape(revision), escapePath(filename))
+}
+
+// escapePath escapes each segment of the slash separated path.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// normalizeETag removes the weak validator prefix and the quotes of the etag.
+func normalizeETag(etag string) string {
+	return strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+}
+
+// verifyFile verifies the size of the file and its checksum against the etag. The etag is the sha256
+// checksum of LFS files and the git blob id of other files. Etags of other formats are not verified.
+func verifyFile(path, etag string, size int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if size >= 0 && stat.Size() != size {
+		return fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", filepath.Base(path), size, stat.Size())
+	}
+
+	var h hash.Hash
+
+	switch len(etag) {
+	case sha256.Size * 2:
+		h = sha256.New()
+	case sha1.Size * 2:
+		h = sha1.New() //nolint:gosec
+		fmt.Fprintf(h, "blob %d\x00", stat.Size())
+	default:
+		return nil
+	}
+
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if checksum := hex.EncodeToString(h.Sum(nil)); checksum != etag {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", etag, checksum)
+	}
+
+	return nil
+}
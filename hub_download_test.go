@@ -0,0 +1,315 @@
+package huggingface
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCommit = "0123456789abcdef0123456789abcdef01234567"
+
+// hubFile represents a file served by the test hub.
+type hubFile struct {
+	content string
+	lfs     bool
+
+	// The etag reported instead of the checksum of the content, to simulate corrupted downloads.
+	etag string
+
+	// Whether the range header of requests is ignored, to simulate servers without range support.
+	ignoreRange bool
+}
+
+// gitBlobID returns the git blob id of the content.
+func gitBlobID(content string) string {
+	h := sha1.New() //nolint:gosec
+	fmt.Fprintf(h, "blob %d\x00%s", len(content), content)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sha256Hex returns the hex encoded sha256 checksum of the content.
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// testHub is a stand-in for the Hub serving the files of the repository org/model at testCommit.
+type testHub struct {
+	*httptest.Server
+
+	// The number of file downloads.
+	downloads int32
+
+	// The number of downloads with a range header.
+	rangeRequests int32
+}
+
+// newTestHub creates a test hub serving the files. LFS files are redirected to a storage path.
+func newTestHub(t *testing.T, files map[string]hubFile) *testHub {
+	hub := &testHub{}
+
+	serveFile := func(w http.ResponseWriter, r *http.Request, name string, file hubFile) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&hub.downloads, 1)
+
+			if r.Header.Get("Range") != "" {
+				atomic.AddInt32(&hub.rangeRequests, 1)
+			}
+		}
+
+		if file.ignoreRange {
+			r.Header.Del("Range")
+		}
+
+		http.ServeContent(w, r, name, time.Time{}, strings.NewReader(file.content))
+	}
+
+	hub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, ok := strings.CutPrefix(r.URL.Path, "/storage/"); ok {
+			serveFile(w, r, name, files[name])
+			return
+		}
+
+		if r.URL.Path == "/api/models/org/model/revision/main" || r.URL.Path == "/api/models/org/model/revision/"+testCommit {
+			assert.Equal(t, "true", r.URL.Query().Get("blobs"))
+
+			siblings := []RepoSibling{}
+
+			for name, file := range files {
+				sibling := RepoSibling{RFilename: name, Size: PTR(int64(len(file.content))), BlobID: gitBlobID(file.content)}
+				if file.lfs {
+					sibling.LFS = &BlobLFSInfo{Size: int64(len(file.content)), SHA256: sha256Hex(file.content)}
+				}
+
+				siblings = append(siblings, sibling)
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "org/model", "sha": testCommit, "siblings": siblings})
+
+			return
+		}
+
+		name, ok := strings.CutPrefix(r.URL.Path, "/org/model/resolve/main/")
+		if !ok {
+			name, ok = strings.CutPrefix(r.URL.Path, "/org/model/resolve/"+testCommit+"/")
+		}
+
+		file, found := files[name]
+		if !ok || !found {
+			w.Header().Set("X-Error-Message", "Entry not found")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Entry not found"}`))
+
+			return
+		}
+
+		w.Header().Set("X-Repo-Commit", testCommit)
+
+		if file.lfs {
+			etag := file.etag
+			if etag == "" {
+				etag = sha256Hex(file.content)
+			}
+
+			w.Header().Set("X-Linked-Etag", fmt.Sprintf("%q", etag))
+			w.Header().Set("X-Linked-Size", strconv.Itoa(len(file.content)))
+			w.Header().Set("Location", hub.URL+"/storage/"+name)
+			w.WriteHeader(http.StatusFound)
+
+			return
+		}
+
+		w.Header().Set("ETag", fmt.Sprintf("%q", gitBlobID(file.content)))
+
+		serveFile(w, r, name, file)
+	}))
+
+	return hub
+}
+
+func TestDownloadFile(t *testing.T) {
+	server := newTestHub(t, map[string]hubFile{
+		"config.json":         {content: `{"model_type":"bert"}`},
+		"onnx/model.onnx":     {content: "onnx weights", lfs: true},
+		"corrupt.safetensors": {content: "weights", lfs: true, etag: sha256Hex("other weights")},
+		"escape.safetensors":  {content: "weights", lfs: true, etag: "../../../escape"},
+	})
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+		o.CacheDir = cacheDir
+		o.Offline = false
+	})
+
+	t.Run("Regular file", func(t *testing.T) {
+		path, err := client.DownloadFile(context.Background(), "org/model", "config.json", "")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(cacheDir, "models--org--model", "snapshots", testCommit, "config.json"), path)
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"model_type":"bert"}`, string(data))
+
+		target, err := os.Readlink(path)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join("..", "..", "blobs", gitBlobID(`{"model_type":"bert"}`)), target)
+
+		ref, err := os.ReadFile(filepath.Join(cacheDir, "models--org--model", "refs", "main"))
+		assert.NoError(t, err)
+		assert.Equal(t, testCommit, string(ref))
+	})
+
+	t.Run("Cached file", func(t *testing.T) {
+		before := atomic.LoadInt32(&server.downloads)
+
+		_, err := client.DownloadFile(context.Background(), "org/model", "config.json", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, before, atomic.LoadInt32(&server.downloads))
+	})
+
+	t.Run("LFS file", func(t *testing.T) {
+		path, err := client.DownloadFile(context.Background(), "org/model", "onnx/model.onnx", "")
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "onnx weights", string(data))
+	})
+
+	t.Run("Custom HTTP client", func(t *testing.T) {
+		var requests int32
+
+		// A wrapper around the default client follows the redirect to the storage of LFS files
+		custom := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = server.URL
+			o.CacheDir = t.TempDir()
+			o.HTTPClient = httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&requests, 1)
+				return http.DefaultClient.Do(req)
+			})
+		})
+
+		path, err := custom.DownloadFile(context.Background(), "org/model", "onnx/model.onnx", "")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(custom.opts.CacheDir, "models--org--model", "snapshots", testCommit, "onnx", "model.onnx"), path)
+		assert.Positive(t, atomic.LoadInt32(&requests))
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "onnx weights", string(data))
+
+		_, err = custom.DownloadFile(context.Background(), "org/model", "missing.json", "")
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		_, err := client.DownloadFile(context.Background(), "org/model", "corrupt.safetensors", "")
+		assert.ErrorContains(t, err, "checksum mismatch")
+		assert.NoFileExists(t, filepath.Join(cacheDir, "models--org--model", "blobs", sha256Hex("other weights")))
+	})
+
+	t.Run("Invalid revision", func(t *testing.T) {
+		for _, revision := range []string{"../../../escape", "refs/../../escape", "/escape"} {
+			_, err := client.DownloadFile(context.Background(), "org/model", "config.json", revision)
+			assert.EqualError(t, err, fmt.Sprintf("invalid revision %q", revision))
+		}
+
+		assert.NoFileExists(t, filepath.Join(cacheDir, "escape"))
+	})
+
+	t.Run("Invalid etag", func(t *testing.T) {
+		_, err := client.DownloadFile(context.Background(), "org/model", "escape.safetensors", "")
+		assert.EqualError(t, err, `invalid etag "../../../escape" in response of the hub`)
+		assert.NoFileExists(t, filepath.Join(cacheDir, "escape"))
+	})
+
+	t.Run("Invalid commit", func(t *testing.T) {
+		commitServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Repo-Commit", "../../escape")
+			w.Header().Set("ETag", fmt.Sprintf("%q", gitBlobID("{}")))
+			_, _ = w.Write([]byte("{}"))
+		}))
+		defer commitServer.Close()
+
+		commitCache := t.TempDir()
+
+		_, err := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = commitServer.URL
+			o.CacheDir = commitCache
+		}).DownloadFile(context.Background(), "org/model", "config.json", "")
+		assert.EqualError(t, err, `invalid commit hash "../../escape" in response of the hub`)
+		assert.NoDirExists(t, filepath.Join(commitCache, "escape"))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := client.DownloadFile(context.Background(), "org/model", "missing.json", "")
+		assert.EqualError(t, err, "huggingfaces error: Entry not found")
+	})
+
+	t.Run("Invalid filename", func(t *testing.T) {
+		_, err := client.DownloadFile(context.Background(), "org/model", "../secret", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		offline := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = server.URL
+			o.CacheDir = cacheDir
+			o.Offline = true
+		})
+
+		path, err := offline.DownloadFile(context.Background(), "org/model", "config.json", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(cacheDir, "models--org--model", "snapshots", testCommit, "config.json"), path)
+
+		_, err = offline.DownloadFile(context.Background(), "org/model", "tokenizer.json", "main")
+		assert.ErrorIs(t, err, ErrNotCached)
+	})
+
+	t.Run("Hub not reachable", func(t *testing.T) {
+		unreachable := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = "http://127.0.0.1:1"
+			o.CacheDir = cacheDir
+		})
+
+		path, err := unreachable.DownloadFile(context.Background(), "org/model", "config.json", "main")
+		assert.NoError(t, err)
+		assert.FileExists(t, path)
+	})
+}
+
+// httpClientFunc is an adapter to use a function as HTTPClient.
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestVerifyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(path, []byte("content"), 0o600))
+
+	assert.NoError(t, verifyFile(path, sha256Hex("content"), 7))
+	assert.NoError(t, verifyFile(path, gitBlobID("content"), -1))
+	assert.Error(t, verifyFile(path, sha256Hex("other"), 7))
+	assert.Error(t, verifyFile(path, sha256Hex("content"), 8))
+}
@@ -0,0 +1,79 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// GGUFMetadataOptions represents options for reading the GGUF metadata of a repository.
+type GGUFMetadataOptions struct {
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+}
+
+// ListGGUFFiles lists the GGUF files of the repository at the specified revision, e.g. the quantized
+// variants of a model. The revision defaults to the main branch if empty.
+func (hc *HubClient) ListGGUFFiles(ctx context.Context, repoID, revision string, optFns ...func(o *GGUFMetadataOptions)) ([]RepoFile, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	opts := ggufMetadataOptions(optFns)
+
+	it := hc.ListRepoFiles(ctx, &ListRepoFilesRequest{
+		RepoID:    repoID,
+		RepoType:  opts.RepoType,
+		Revision:  revision,
+		Recursive: true,
+	})
+
+	files := []RepoFile{}
+
+	for it.Next() {
+		if file := it.Value(); file.Type == "file" && strings.HasSuffix(strings.ToLower(file.Path), ".gguf") {
+			files = append(files, file)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// GGUFMetadata reads the header of a GGUF file of the repository at the specified revision, which
+// contains the metadata and the tensor index. Only the header is fetched with range requests.
+// The revision defaults to the main branch if empty.
+func (hc *HubClient) GGUFMetadata(ctx context.Context, repoID, filename, revision string, optFns ...func(o *GGUFMetadataOptions)) (*GGUFFile, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	if filename == "" {
+		return nil, errors.New("filename is required")
+	}
+
+	opts := ggufMetadataOptions(optFns)
+	fileURL := hc.resolveFileURL(opts.RepoType, repoID, filename, revisionOrMain(revision))
+
+	return ReadGGUF(&httpRangeReader{
+		ctx:       ctx,
+		transport: hc.transportFor(fileURL),
+		url:       fileURL,
+		size:      -1,
+	})
+}
+
+func ggufMetadataOptions(optFns []func(o *GGUFMetadataOptions)) GGUFMetadataOptions {
+	opts := GGUFMetadataOptions{
+		RepoType: RepoTypeModel,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return opts
+}
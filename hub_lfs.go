@@ -0,0 +1,269 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lfsAction represents an action of the LFS batch API, e.g. an upload.
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// lfsObject represents an object of the LFS batch API.
+type lfsObject struct {
+	OID     string               `json:"oid"`
+	Size    int64                `json:"size"`
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// uploadLFSFiles uploads the files to the LFS storage of the repository. Files that are already
+// stored are skipped. Large files are uploaded in parts if the upload action specifies a chunk size.
+func (hc *HubClient) uploadLFSFiles(ctx context.Context, repoType RepoType, repoID, revision string, files []*addFileOperation) error {
+	const batchSize = 256
+
+	for start := 0; start < len(files); start += batchSize {
+		end := start + batchSize
+		if end > len(files) {
+			end = len(files)
+		}
+
+		objects, err := hc.lfsBatch(ctx, repoType, repoID, revision, files[start:end])
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			if object.Error != nil {
+				return fmt.Errorf("lfs object %s: %s (%d)", object.OID, object.Error.Message, object.Error.Code)
+			}
+
+			upload, ok := object.Actions["upload"]
+			if !ok {
+				// The object is already stored
+				continue
+			}
+
+			file := findLFSFile(files[start:end], object.OID)
+			if file == nil {
+				return fmt.Errorf("unexpected lfs object %s", object.OID)
+			}
+
+			if _, ok := upload.Header["chunk_size"]; ok {
+				err = hc.uploadLFSMultipart(ctx, file, upload)
+			} else {
+				err = hc.uploadLFSBasic(ctx, file, upload)
+			}
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", file.pathInRepo, err)
+			}
+
+			if verify, ok := object.Actions["verify"]; ok {
+				if err := hc.verifyLFSObject(ctx, object, verify); err != nil {
+					return fmt.Errorf("%s: %w", file.pathInRepo, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// lfsBatch requests the upload actions of the files from the LFS batch API.
+func (hc *HubClient) lfsBatch(ctx context.Context, repoType RepoType, repoID, revision string, files []*addFileOperation) ([]lfsObject, error) {
+	objects := make([]lfsObject, len(files))
+	for i, file := range files {
+		objects[i] = lfsObject{OID: file.sha256, Size: file.size}
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"operation": "upload",
+		"transfers": []string{"basic", "multipart"},
+		"objects":   objects,
+		"hash_algo": "sha256",
+		"ref":       map[string]string{"name": revision},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s.git/info/lfs/objects/batch", hc.repoURL(repoType, repoID))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/vnd.git-lfs+json")
+	httpReq.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+
+	body, err := hc.do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res := struct {
+		Objects []lfsObject `json:"objects"`
+	}{}
+
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Objects, nil
+}
+
+// uploadLFSBasic uploads the file with a single request.
+func (hc *HubClient) uploadLFSBasic(ctx context.Context, file *addFileOperation, upload lfsAction) error {
+	r, err := file.open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, upload.Href, r)
+	if err != nil {
+		return err
+	}
+
+	httpReq.ContentLength = file.size
+
+	for key, value := range upload.Header {
+		httpReq.Header.Set(key, value)
+	}
+
+	// The storage is authorized by the signed URL and must not receive the token
+	t := transport{httpClient: hc.httpClient}
+
+	_, err = t.do(httpReq)
+
+	return err
+}
+
+// uploadLFSMultipart uploads the file in parts to the signed URLs of the upload action. The header of the
+// action contains the chunk size and the URL of each part, keyed by the part number. The upload is completed
+// with the etags of the parts.
+func (hc *HubClient) uploadLFSMultipart(ctx context.Context, file *addFileOperation, upload lfsAction) error {
+	chunkSize, err := strconv.ParseInt(upload.Header["chunk_size"], 10, 64)
+	if err != nil || chunkSize <= 0 {
+		return fmt.Errorf("invalid chunk size %q", upload.Header["chunk_size"])
+	}
+
+	partNumbers := []int{}
+
+	for key := range upload.Header {
+		if n, err := strconv.Atoi(key); err == nil {
+			partNumbers = append(partNumbers, n)
+		}
+	}
+
+	sort.Ints(partNumbers)
+
+	r, err := file.open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	type part struct {
+		PartNumber int    `json:"partNumber"`
+		ETag       string `json:"etag"`
+	}
+
+	parts := []part{}
+	t := transport{httpClient: hc.httpClient}
+
+	for i, n := range partNumbers {
+		if _, err := r.Seek(int64(i)*chunkSize, io.SeekStart); err != nil {
+			return err
+		}
+
+		chunk, err := io.ReadAll(io.LimitReader(r, chunkSize))
+		if err != nil {
+			return err
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, upload.Header[strconv.Itoa(n)], bytes.NewReader(chunk))
+		if err != nil {
+			return err
+		}
+
+		res, err := t.send(httpReq)
+		if err != nil {
+			return fmt.Errorf("part %d: %w", n, err)
+		}
+
+		res.Body.Close()
+
+		etag := res.Header.Get("ETag")
+		if etag == "" {
+			return fmt.Errorf("part %d: missing etag in response of the storage", n)
+		}
+
+		parts = append(parts, part{PartNumber: n, ETag: strings.Trim(etag, `"`)})
+	}
+
+	payload, err := json.Marshal(map[string]any{"oid": file.sha256, "parts": parts})
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, upload.Href, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Accept", "application/vnd.git-lfs+json")
+	httpReq.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+
+	_, err = t.do(httpReq)
+
+	return err
+}
+
+// verifyLFSObject asks the Hub to verify that the object is stored.
+func (hc *HubClient) verifyLFSObject(ctx context.Context, object lfsObject, verify lfsAction) error {
+	payload, err := json.Marshal(map[string]any{"oid": object.OID, "size": object.Size})
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, verify.Href, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+
+	for key, value := range verify.Header {
+		httpReq.Header.Set(key, value)
+	}
+
+	_, err = hc.do(httpReq)
+
+	return err
+}
+
+// findLFSFile returns the file with the sha256 checksum.
+func findLFSFile(files []*addFileOperation, oid string) *addFileOperation {
+	for _, file := range files {
+		if file.sha256 == oid {
+			return file
+		}
+	}
+
+	return nil
+}
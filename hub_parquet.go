@@ -0,0 +1,229 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parquetTailSize is the number of bytes fetched from the end of remote parquet files when they are
+// opened, which usually contains the whole footer.
+const parquetTailSize = 64 * 1024
+
+// ListParquetFiles returns the URLs of the parquet files the Hub converted the dataset to, by
+// configuration and split.
+func (hc *HubClient) ListParquetFiles(ctx context.Context, repoID string) (map[string]map[string][]string, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	body, err := hc.get(ctx, fmt.Sprintf("/api/datasets/%s/parquet", repoID))
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]map[string][]string{}
+	if err := json.Unmarshal(body, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// OpenParquetFile opens the remote parquet file at the URL, e.g. one returned by ListParquetFiles.
+// The footer is fetched immediately, while the column chunks are fetched with range requests when
+// row groups are read. The context is used for all requests of the returned reader.
+func (hc *HubClient) OpenParquetFile(ctx context.Context, fileURL string) (*ParquetReader, error) {
+	r := &httpRangeReader{
+		ctx:       ctx,
+		transport: hc.transportFor(fileURL),
+		url:       fileURL,
+	}
+
+	if err := r.readTail(parquetTailSize); err != nil {
+		return nil, err
+	}
+
+	return NewParquetReader(r, r.size)
+}
+
+// Used with ParquetRows
+type ParquetRowsRequest struct {
+	// (Required) The id of the dataset, e.g. stanfordnlp/imdb.
+	RepoID string
+
+	// (Required) The configuration of the dataset.
+	Config string
+
+	// (Required) The split of the configuration.
+	Split string
+
+	// The top-level columns to read. All columns are read if empty.
+	Columns []string
+
+	// (Default: 0). The index of the first row. Row groups before the offset are skipped without
+	// fetching their column chunks.
+	Offset int64
+
+	// (Default: 0). The maximum number of rows. Zero iterates until the end of the split.
+	Limit int
+}
+
+// ParquetRows iterates over the rows of a split of the dataset, read from the parquet files the Hub
+// converted the dataset to. The files are streamed with range requests one row group at a time, so
+// only the requested columns of the iterated row groups are transferred.
+func (hc *HubClient) ParquetRows(ctx context.Context, req *ParquetRowsRequest) *Iterator[DatasetRow] {
+	if req.RepoID == "" {
+		return errIterator[DatasetRow](errors.New("repoID is required"))
+	}
+
+	if req.Config == "" {
+		return errIterator[DatasetRow](errors.New("config is required"))
+	}
+
+	if req.Split == "" {
+		return errIterator[DatasetRow](errors.New("split is required"))
+	}
+
+	listPath := fmt.Sprintf("/api/datasets/%s/parquet/%s/%s", req.RepoID, url.PathEscape(req.Config), url.PathEscape(req.Split))
+	urls := []string{}
+
+	// The files are listed when the first rows are fetched
+	files := func(ctx context.Context) (int, error) {
+		body, err := hc.get(ctx, listPath)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := json.Unmarshal(body, &urls); err != nil {
+			return 0, err
+		}
+
+		return len(urls), nil
+	}
+
+	return newParquetRowIterator(ctx, files, func(ctx context.Context, file int) (*ParquetReader, error) {
+		return hc.OpenParquetFile(ctx, urls[file])
+	}, req.Columns, req.Offset, req.Limit)
+}
+
+// httpRangeReader reads a remote file with range requests. The tail of the file, which is read
+// first to determine its size, is kept in memory. A negative size means that the size is unknown.
+type httpRangeReader struct {
+	ctx       context.Context
+	transport *transport
+	url       string
+	size      int64
+
+	tail       []byte
+	tailOffset int64
+}
+
+// readTail reads the last n bytes of the file and its size.
+func (r *httpRangeReader) readTail(n int) error {
+	res, err := r.get(fmt.Sprintf("bytes=-%d", n))
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	r.tail, err = io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	// Servers without support for range requests respond with the whole file
+	r.size = int64(len(r.tail))
+
+	if res.StatusCode == http.StatusPartialContent {
+		if r.size, err = parseContentRangeSize(res.Header.Get("Content-Range")); err != nil {
+			return err
+		}
+	}
+
+	r.tailOffset = r.size - int64(len(r.tail))
+
+	return nil
+}
+
+// ReadAt reads len(p) bytes at offset off of the file.
+func (r *httpRangeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	end := off + int64(len(p))
+
+	if r.size >= 0 {
+		if off >= r.size {
+			return 0, io.EOF
+		}
+
+		if end > r.size {
+			end = r.size
+		}
+
+		if r.tail != nil && off >= r.tailOffset {
+			n := copy(p, r.tail[off-r.tailOffset:end-r.tailOffset])
+			if n < len(p) {
+				return n, io.EOF
+			}
+
+			return n, nil
+		}
+	}
+
+	res, err := r.get(fmt.Sprintf("bytes=%d-%d", off, end-1))
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusPartialContent {
+		return 0, errRangeNotSupported
+	}
+
+	n, err := io.ReadFull(res.Body, p[:end-off])
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	if err != nil {
+		return n, err
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (r *httpRangeReader) get(byteRange string) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Range", byteRange)
+
+	return r.transport.send(httpReq)
+}
+
+// parseContentRangeSize returns the total size from a Content-Range header, e.g. bytes 0-99/1234.
+func parseContentRangeSize(header string) (int64, error) {
+	i := strings.LastIndexByte(header, '/')
+	if i < 0 || !strings.HasPrefix(header, "bytes ") {
+		return 0, fmt.Errorf("invalid content range %q", header)
+	}
+
+	return strconv.ParseInt(header[i+1:], 10, 64)
+}
@@ -0,0 +1,176 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Request structure for CreateRepo
+type CreateRepoRequest struct {
+	// (Required) The id of the repository, e.g. org/name.
+	RepoID string `json:"-"`
+
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType `json:"-"`
+
+	// (Default: false). Whether the repository is private.
+	Private bool `json:"private"`
+
+	// The SDK of the space, one of gradio, streamlit, docker or static. Required for spaces.
+	SpaceSDK string `json:"sdk,omitempty"`
+
+	// (Default: false). Whether no error is returned if the repository already exists.
+	ExistOK bool `json:"-"`
+}
+
+// Response structure for CreateRepo
+type CreateRepoResponse struct {
+	// The URL of the repository.
+	URL string `json:"url"`
+}
+
+// CreateRepo creates a repository on the Hub.
+func (hc *HubClient) CreateRepo(ctx context.Context, req *CreateRepoRequest) (*CreateRepoResponse, error) {
+	if req.RepoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	organization, name := splitRepoID(req.RepoID)
+
+	payload := struct {
+		*CreateRepoRequest
+		Name         string   `json:"name"`
+		Organization string   `json:"organization,omitempty"`
+		Type         RepoType `json:"type,omitempty"`
+	}{
+		CreateRepoRequest: req,
+		Name:              name,
+		Organization:      organization,
+		Type:              nonModelRepoType(req.RepoType),
+	}
+
+	body, err := hc.post(ctx, "/api/repos/create", payload)
+	if err != nil {
+		var httpErr *HTTPError
+		if req.ExistOK && errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict {
+			return &CreateRepoResponse{URL: hc.repoURL(req.RepoType, req.RepoID)}, nil
+		}
+
+		return nil, err
+	}
+
+	createRepoResponse := CreateRepoResponse{}
+	if err := json.Unmarshal(body, &createRepoResponse); err != nil {
+		return nil, err
+	}
+
+	return &createRepoResponse, nil
+}
+
+// Request structure for DeleteRepo
+type DeleteRepoRequest struct {
+	// (Required) The id of the repository, e.g. org/name.
+	RepoID string
+
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+
+	// (Default: false). Whether no error is returned if the repository does not exist.
+	MissingOK bool
+}
+
+// DeleteRepo deletes a repository from the Hub. This can not be undone.
+func (hc *HubClient) DeleteRepo(ctx context.Context, req *DeleteRepoRequest) error {
+	if req.RepoID == "" {
+		return errors.New("repoID is required")
+	}
+
+	organization, name := splitRepoID(req.RepoID)
+
+	payload := struct {
+		Name         string   `json:"name"`
+		Organization string   `json:"organization,omitempty"`
+		Type         RepoType `json:"type,omitempty"`
+	}{
+		Name:         name,
+		Organization: organization,
+		Type:         nonModelRepoType(req.RepoType),
+	}
+
+	_, err := hc.sendJSON(ctx, http.MethodDelete, "/api/repos/delete", payload)
+	if err != nil {
+		var httpErr *HTTPError
+		if req.MissingOK && errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Request structure for UpdateRepoVisibility
+type UpdateRepoVisibilityRequest struct {
+	// (Required) The id of the repository, e.g. org/name.
+	RepoID string `json:"-"`
+
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType `json:"-"`
+
+	// Whether the repository is private.
+	Private bool `json:"private"`
+}
+
+// UpdateRepoVisibility makes a repository private or public.
+func (hc *HubClient) UpdateRepoVisibility(ctx context.Context, req *UpdateRepoVisibilityRequest) error {
+	if req.RepoID == "" {
+		return errors.New("repoID is required")
+	}
+
+	_, err := hc.sendJSON(ctx, http.MethodPut, fmt.Sprintf("%s/settings", hc.repoAPIPath(req.RepoType, req.RepoID)), req)
+
+	return err
+}
+
+// repoAPIPath returns the path of the API of the repository, e.g. /api/models/org/name.
+func (hc *HubClient) repoAPIPath(repoType RepoType, repoID string) string {
+	if repoType == "" {
+		repoType = RepoTypeModel
+	}
+
+	return fmt.Sprintf("/api/%ss/%s", repoType, repoID)
+}
+
+// repoURL returns the URL of the repository, e.g. https://huggingface.co/datasets/org/name.
+func (hc *HubClient) repoURL(repoType RepoType, repoID string) string {
+	prefix := ""
+	if t := nonModelRepoType(repoType); t != "" {
+		prefix = string(t) + "s/"
+	}
+
+	return fmt.Sprintf("%s/%s%s", hc.endpoint, prefix, repoID)
+}
+
+// nonModelRepoType returns the repository type, or an empty type for models, which are the default of the API.
+func nonModelRepoType(repoType RepoType) RepoType {
+	if repoType == RepoTypeModel {
+		return ""
+	}
+
+	return repoType
+}
+
+// splitRepoID splits the repository id into the organization or user and the name of the repository.
+func splitRepoID(repoID string) (string, string) {
+	for i := len(repoID) - 1; i >= 0; i-- {
+		if repoID[i] == '/' {
+			return repoID[:i], repoID[i+1:]
+		}
+	}
+
+	return "", repoID
+}
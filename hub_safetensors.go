@@ -0,0 +1,110 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SafetensorsMetadataOptions represents options for reading the safetensors metadata of a repository.
+type SafetensorsMetadataOptions struct {
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+}
+
+// SafetensorsFileMetadata reads the header of a safetensors file of the repository at the specified
+// revision. Only the 8 byte length prefix and the JSON header are fetched with range requests.
+// The revision defaults to the main branch if empty.
+func (hc *HubClient) SafetensorsFileMetadata(ctx context.Context, repoID, filename, revision string, optFns ...func(o *SafetensorsMetadataOptions)) (*SafetensorsFileMetadata, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	if filename == "" {
+		return nil, errors.New("filename is required")
+	}
+
+	opts := safetensorsMetadataOptions(optFns)
+
+	return hc.readSafetensorsMetadata(ctx, opts.RepoType, repoID, filename, revisionOrMain(revision))
+}
+
+// SafetensorsMetadata reads the headers of the safetensors checkpoint of the repository at the specified
+// revision without downloading the weights. Sharded checkpoints are read by following the
+// model.safetensors.index.json index. The revision defaults to the main branch if empty.
+func (hc *HubClient) SafetensorsMetadata(ctx context.Context, repoID, revision string, optFns ...func(o *SafetensorsMetadataOptions)) (*SafetensorsRepoMetadata, error) {
+	if repoID == "" {
+		return nil, errors.New("repoID is required")
+	}
+
+	opts := safetensorsMetadataOptions(optFns)
+	revision = revisionOrMain(revision)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, hc.resolveFileURL(opts.RepoType, repoID, safetensorsIndexFile, revision), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := hc.do(httpReq)
+	if err == nil {
+		return readShardedSafetensorsMetadata(index, func(filename string) (*SafetensorsFileMetadata, error) {
+			return hc.readSafetensorsMetadata(ctx, opts.RepoType, repoID, filename, revision)
+		})
+	}
+
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	metadata, err := hc.readSafetensorsMetadata(ctx, opts.RepoType, repoID, "model.safetensors", revision)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%s has neither model.safetensors nor %s", repoID, safetensorsIndexFile)
+		}
+
+		return nil, err
+	}
+
+	return newSafetensorsRepoMetadata("model.safetensors", metadata), nil
+}
+
+// readSafetensorsMetadata reads the header of a safetensors file of the repository with range requests.
+func (hc *HubClient) readSafetensorsMetadata(ctx context.Context, repoType RepoType, repoID, filename, revision string) (*SafetensorsFileMetadata, error) {
+	fileURL := hc.resolveFileURL(repoType, repoID, filename, revision)
+
+	return ReadSafetensorsMetadata(&httpRangeReader{
+		ctx:       ctx,
+		transport: hc.transportFor(fileURL),
+		url:       fileURL,
+		size:      -1,
+	})
+}
+
+func safetensorsMetadataOptions(optFns []func(o *SafetensorsMetadataOptions)) SafetensorsMetadataOptions {
+	opts := SafetensorsMetadataOptions{
+		RepoType: RepoTypeModel,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return opts
+}
+
+// revisionOrMain returns the revision or the main branch if the revision is empty.
+func revisionOrMain(revision string) string {
+	if revision == "" {
+		return "main"
+	}
+
+	return revision
+}
+
+// isNotFound reports whether the error is an HTTPError with status 404.
+func isNotFound(err error) bool {
+	var httpErr *HTTPError
+
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,404 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DownloadProgress reports the progress of a snapshot download.
+type DownloadProgress struct {
+	// The file the progress is reported for.
+	Filename string
+
+	// The number of bytes of the file that are downloaded.
+	FileBytes int64
+
+	// The size of the file in bytes, or -1 if unknown.
+	FileSize int64
+
+	// The number of bytes of all files that are downloaded.
+	Bytes int64
+
+	// The size of all files in bytes. Files of unknown size are not included.
+	Size int64
+}
+
+// SnapshotDownloadOptions represents options for SnapshotDownload.
+type SnapshotDownloadOptions struct {
+	// (Default: RepoTypeModel). The type of the repository.
+	RepoType RepoType
+
+	// Only files matching at least one of the glob patterns are downloaded. All files are downloaded if empty.
+	// The wildcard * matches any sequence of characters including slashes, and patterns ending with a slash
+	// match all files of the directory.
+	AllowPatterns []string
+
+	// Files matching any of the glob patterns are not downloaded.
+	IgnorePatterns []string
+
+	// (Default: 8). The maximum number of files downloaded concurrently.
+	MaxWorkers int
+
+	// (Default: 64MiB). Files larger than the chunk size are downloaded in chunks with concurrent range
+	// requests. A negative chunk size disables chunking.
+	ChunkSize int64
+
+	// (Default: 4). The maximum number of chunks of a file downloaded concurrently.
+	MaxChunkWorkers int
+
+	// (Default: false). Whether files are downloaded even if they are already cached.
+	ForceDownload bool
+
+	// Called whenever the download of a file progresses. Calls are serialized. The number of bytes
+	// decreases if the server does not support range requests and the chunks of a file are discarded.
+	Progress func(p DownloadProgress)
+}
+
+// SnapshotDownload downloads the files of a repository at the specified revision into the local cache and
+// returns the directory of the snapshot. Files are downloaded concurrently, large files in chunks, and
+// interrupted downloads are resumed. The revision is a branch, tag or commit hash and defaults to main if empty.
+// In offline mode the snapshot is resolved from the cache only.
+func (hc *HubClient) SnapshotDownload(ctx context.Context, repoID, revision string, optFns ...func(o *SnapshotDownloadOptions)) (string, error) {
+	opts := SnapshotDownloadOptions{
+		RepoType:        RepoTypeModel,
+		MaxWorkers:      8,
+		ChunkSize:       64 << 20,
+		MaxChunkWorkers: 4,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if revision == "" {
+		revision = "main"
+	}
+
+	if err := checkRevision(revision); err != nil {
+		return "", err
+	}
+
+	allowPatterns, err := compileGlobs(opts.AllowPatterns)
+	if err != nil {
+		return "", err
+	}
+
+	ignorePatterns, err := compileGlobs(opts.IgnorePatterns)
+	if err != nil {
+		return "", err
+	}
+
+	rc, err := newRepoCache(hc.opts.CacheDir, opts.RepoType, repoID)
+	if err != nil {
+		return "", err
+	}
+
+	if hc.opts.Offline {
+		return rc.cachedSnapshot(revision)
+	}
+
+	commit, siblings, err := hc.repoFiles(ctx, rc.repoType, repoID, revision)
+	if err != nil {
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) && ctx.Err() == nil {
+			if dir, cacheErr := rc.cachedSnapshot(revision); cacheErr == nil {
+				return dir, nil
+			}
+		}
+
+		return "", err
+	}
+
+	if err := rc.writeRef(revision, commit); err != nil {
+		return "", err
+	}
+
+	files := []RepoSibling{}
+
+	for _, sibling := range siblings {
+		if matchesGlobs(sibling.RFilename, allowPatterns, true) && !matchesGlobs(sibling.RFilename, ignorePatterns, false) {
+			files = append(files, sibling)
+		}
+	}
+
+	tracker := newProgressTracker(files, opts.Progress)
+
+	transfer := blobTransfer{
+		chunkConcurrency: opts.MaxChunkWorkers,
+	}
+
+	if opts.ChunkSize > 0 {
+		transfer.chunkSize = opts.ChunkSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxInt(opts.MaxWorkers, 1))
+
+	for _, file := range files {
+		wg.Add(1)
+
+		go func(file RepoSibling) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			fileTransfer := transfer
+			fileTransfer.progress = func(n int64) {
+				tracker.add(file.RFilename, n)
+			}
+
+			if err := hc.downloadSnapshotFile(ctx, rc, opts, commit, file, fileTransfer); err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("%s: %w", file.RFilename, err)
+
+					cancel()
+				})
+			}
+		}(file)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	// Files are skipped if the context of the caller is canceled
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	dir := rc.snapshotPath(commit, "")
+
+	// Creates the snapshot directory even if no file matches
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// downloadSnapshotFile downloads a file of the snapshot into the cache. The metadata of the file is
+// taken from the listing of the repository if it contains the etag, otherwise it is requested.
+func (hc *HubClient) downloadSnapshotFile(ctx context.Context, rc *repoCache, opts SnapshotDownloadOptions, commit string, file RepoSibling, transfer blobTransfer) error {
+	metadata := &fileMetadata{
+		commit:   commit,
+		etag:     file.BlobID,
+		size:     siblingSize(file),
+		location: hc.resolveFileURL(rc.repoType, rc.repoID, file.RFilename, commit),
+	}
+
+	if file.LFS != nil {
+		metadata.etag = file.LFS.SHA256
+	}
+
+	if metadata.etag == "" {
+		var err error
+
+		metadata, err = hc.fileMetadata(ctx, rc.repoType, rc.repoID, file.RFilename, commit)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := hc.downloadToCache(ctx, rc, file.RFilename, commit, metadata, opts.ForceDownload, transfer)
+
+	return err
+}
+
+// repoFiles returns the commit of the revision and the files of the repository.
+func (hc *HubClient) repoFiles(ctx context.Context, repoType RepoType, repoID, revision string) (string, []RepoSibling, error) {
+	body, err := hc.get(ctx, fmt.Sprintf("/api/%ss/%s/revision/%s?blobs=true", repoType, repoID, url.PathEscape(revision)))
+	if err != nil {
+		return "", nil, err
+	}
+
+	info := struct {
+		SHA      string        `json:"sha"`
+		Siblings []RepoSibling `json:"siblings"`
+	}{}
+
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", nil, err
+	}
+
+	if info.SHA == "" {
+		return "", nil, errors.New("missing commit hash in response of the hub")
+	}
+
+	if !commitHashRegexp.MatchString(info.SHA) {
+		return "", nil, fmt.Errorf("invalid commit hash %q in response of the hub", info.SHA)
+	}
+
+	return info.SHA, info.Siblings, nil
+}
+
+// siblingSize returns the size of the file, or -1 if unknown.
+func siblingSize(file RepoSibling) int64 {
+	if file.LFS != nil {
+		return file.LFS.Size
+	}
+
+	if file.Size != nil {
+		return *file.Size
+	}
+
+	return -1
+}
+
+// progressTracker aggregates the progress of concurrent file downloads.
+type progressTracker struct {
+	mu       sync.Mutex
+	fn       func(p DownloadProgress)
+	sizes    map[string]int64
+	progress map[string]int64
+	bytes    int64
+	size     int64
+}
+
+// newProgressTracker creates a tracker for the download of the files, which reports to fn.
+func newProgressTracker(files []RepoSibling, fn func(p DownloadProgress)) *progressTracker {
+	t := &progressTracker{
+		fn:       fn,
+		sizes:    make(map[string]int64, len(files)),
+		progress: make(map[string]int64, len(files)),
+	}
+
+	for _, file := range files {
+		size := siblingSize(file)
+
+		t.sizes[file.RFilename] = size
+		if size > 0 {
+			t.size += size
+		}
+	}
+
+	return t
+}
+
+// add adds the number of downloaded bytes of the file and reports the progress.
+func (t *progressTracker) add(filename string, n int64) {
+	if t.fn == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress[filename] += n
+	t.bytes += n
+
+	t.fn(DownloadProgress{
+		Filename:  filename,
+		FileBytes: t.progress[filename],
+		FileSize:  t.sizes[filename],
+		Bytes:     t.bytes,
+		Size:      t.size,
+	})
+}
+
+// compileGlobs compiles the glob patterns to regular expressions.
+func compileGlobs(patterns []string) ([]*regexp.Regexp, error) {
+	globs := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		glob, err := globRegexp(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		globs = append(globs, glob)
+	}
+
+	return globs, nil
+}
+
+// matchesGlobs reports whether the path matches any of the compiled glob patterns. It returns
+// the default value if there are no patterns.
+func matchesGlobs(path string, globs []*regexp.Regexp, def bool) bool {
+	if len(globs) == 0 {
+		return def
+	}
+
+	for _, glob := range globs {
+		if glob.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// globRegexp converts the glob pattern to a regular expression. Like fnmatch the wildcard * matches
+// slashes, and patterns ending with a slash match everything below the directory.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	expr := pattern
+	if strings.HasSuffix(expr, "/") {
+		expr += "*"
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("^")
+
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; c {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		case '[':
+			if j := strings.IndexByte(expr[i+1:], ']'); j >= 0 {
+				class := expr[i+1 : i+1+j]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+
+				sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+				i += j + 1
+			} else {
+				sb.WriteString(`\[`)
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	return re, nil
+}
+
+// maxInt returns the larger of the integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
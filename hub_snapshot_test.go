@@ -0,0 +1,205 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotDownload(t *testing.T) {
+	weights := strings.Repeat("0123456789", 100)
+
+	server := newTestHub(t, map[string]hubFile{
+		"config.json":                {content: `{"model_type":"bert"}`},
+		"tokenizer.json":             {content: `{"version":"1.0"}`},
+		"model.safetensors":          {content: weights, lfs: true},
+		"onnx/model.onnx":            {content: "onnx weights", lfs: true},
+		"pytorch_model.bin":          {content: "pickle", lfs: true},
+		"onnx/model_quantized.onnx":  {content: "quantized", lfs: true},
+		"original/consolidated.pth":  {content: "original", lfs: true},
+		"original/params/extra.json": {content: "{}"},
+	})
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+		o.CacheDir = cacheDir
+		o.Offline = false
+	})
+
+	t.Run("Patterns, chunks and progress", func(t *testing.T) {
+		var last DownloadProgress
+
+		dir, err := client.SnapshotDownload(context.Background(), "org/model", "", func(o *SnapshotDownloadOptions) {
+			o.AllowPatterns = []string{"*.json", "*.safetensors", "onnx/"}
+			o.IgnorePatterns = []string{"*quantized*"}
+			o.ChunkSize = 300
+			o.Progress = func(p DownloadProgress) {
+				last = p
+			}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(cacheDir, "models--org--model", "snapshots", testCommit), dir)
+
+		for _, name := range []string{"config.json", "tokenizer.json", "model.safetensors", "onnx/model.onnx", "original/params/extra.json"} {
+			assert.FileExists(t, filepath.Join(dir, name))
+		}
+
+		for _, name := range []string{"pytorch_model.bin", "onnx/model_quantized.onnx", "original/consolidated.pth"} {
+			assert.NoFileExists(t, filepath.Join(dir, name))
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+		assert.NoError(t, err)
+		assert.Equal(t, weights, string(data))
+
+		// The weights are downloaded in four chunks
+		assert.Equal(t, int32(4), atomic.LoadInt32(&server.rangeRequests))
+
+		assert.Equal(t, last.Size, last.Bytes)
+		assert.Equal(t, int64(len(weights)+len(`{"model_type":"bert"}`)+len(`{"version":"1.0"}`)+len("onnx weights")+len("{}")), last.Size)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		resumeCache := t.TempDir()
+
+		resumeClient := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = server.URL
+			o.CacheDir = resumeCache
+			o.Offline = false
+		})
+
+		blobs := filepath.Join(resumeCache, "models--org--model", "blobs")
+		assert.NoError(t, os.MkdirAll(blobs, 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(blobs, sha256Hex(weights)+".incomplete"), []byte(weights[:600]), 0o600))
+
+		before := atomic.LoadInt32(&server.rangeRequests)
+
+		dir, err := resumeClient.SnapshotDownload(context.Background(), "org/model", "main", func(o *SnapshotDownloadOptions) {
+			o.AllowPatterns = []string{"model.safetensors"}
+			o.ChunkSize = -1
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&server.rangeRequests)-before)
+
+		data, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+		assert.NoError(t, err)
+		assert.Equal(t, weights, string(data))
+		assert.NoFileExists(t, filepath.Join(blobs, sha256Hex(weights)+".incomplete"))
+	})
+
+	t.Run("Resume without range support", func(t *testing.T) {
+		rangeServer := newTestHub(t, map[string]hubFile{
+			"model.safetensors": {content: weights, lfs: true, ignoreRange: true},
+		})
+		defer rangeServer.Close()
+
+		resumeCache := t.TempDir()
+
+		resumeClient := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = rangeServer.URL
+			o.CacheDir = resumeCache
+		})
+
+		// The first chunks were downloaded before, but the last chunk can not be requested
+		blobs := filepath.Join(resumeCache, "models--org--model", "blobs")
+		assert.NoError(t, os.MkdirAll(blobs, 0o755))
+
+		for i := 0; i < 3; i++ {
+			part := filepath.Join(blobs, fmt.Sprintf("%s.incomplete.part%d", sha256Hex(weights), i))
+			assert.NoError(t, os.WriteFile(part, []byte(weights[i*300:(i+1)*300]), 0o600))
+		}
+
+		var last DownloadProgress
+
+		dir, err := resumeClient.SnapshotDownload(context.Background(), "org/model", "main", func(o *SnapshotDownloadOptions) {
+			o.RepoType = ""
+			o.ChunkSize = 300
+			o.Progress = func(p DownloadProgress) {
+				assert.LessOrEqual(t, p.Bytes, p.Size)
+				last = p
+			}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(weights)), last.Bytes)
+		assert.Equal(t, int64(len(weights)), last.FileBytes)
+
+		data, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+		assert.NoError(t, err)
+		assert.Equal(t, weights, string(data))
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.Endpoint = server.URL
+			o.CacheDir = t.TempDir()
+		}).SnapshotDownload(ctx, "org/model", "main", func(o *SnapshotDownloadOptions) {
+			o.MaxWorkers = 1
+			o.Progress = func(p DownloadProgress) {
+				// Cancels the download once the first file is complete
+				if p.FileBytes == p.FileSize {
+					cancel()
+				}
+			}
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Invalid revision", func(t *testing.T) {
+		_, err := client.SnapshotDownload(context.Background(), "org/model", "../escape")
+		assert.EqualError(t, err, `invalid revision "../escape"`)
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		_, err := client.SnapshotDownload(context.Background(), "org/model", "main", func(o *SnapshotDownloadOptions) {
+			o.AllowPatterns = []string{"[z-a].json"}
+		})
+		assert.ErrorContains(t, err, `invalid pattern "[z-a].json"`)
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		offline := NewHubClient("your-token", func(o *HubClientOptions) {
+			o.CacheDir = cacheDir
+			o.Offline = true
+		})
+
+		dir, err := offline.SnapshotDownload(context.Background(), "org/model", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(cacheDir, "models--org--model", "snapshots", testCommit), dir)
+
+		_, err = offline.SnapshotDownload(context.Background(), "org/other", "main")
+		assert.ErrorIs(t, err, ErrNotCached)
+	})
+}
+
+func TestMatchesGlobs(t *testing.T) {
+	matchesPatterns := func(path string, patterns []string, def bool) bool {
+		globs, err := compileGlobs(patterns)
+		assert.NoError(t, err)
+
+		return matchesGlobs(path, globs, def)
+	}
+
+	assert.True(t, matchesPatterns("onnx/model.onnx", nil, true))
+	assert.True(t, matchesPatterns("onnx/model.onnx", []string{"*.onnx"}, false))
+	assert.True(t, matchesPatterns("onnx/model.onnx", []string{"onnx/"}, false))
+	assert.True(t, matchesPatterns("model-00001.safetensors", []string{"model-0000[0-9].safetensors"}, false))
+	assert.False(t, matchesPatterns("model.bin", []string{"*.onnx", "*.safetensors"}, false))
+	assert.False(t, matchesPatterns("config.json", []string{"config.js"}, false))
+
+	for _, pattern := range []string{"[]", "[z-a]"} {
+		_, err := compileGlobs([]string{"*.json", pattern})
+		assert.ErrorContains(t, err, fmt.Sprintf("invalid pattern %q", pattern))
+	}
+}
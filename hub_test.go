@@ -0,0 +1,155 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListModels(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/models", r.URL.Path)
+
+		if r.URL.Query().Get("cursor") == "" {
+			assert.Equal(t, "bert", r.URL.Query().Get("search"))
+			assert.Equal(t, "fill-mask", r.URL.Query().Get("pipeline_tag"))
+			assert.Equal(t, []string{"pytorch", "en"}, r.URL.Query()["filter"])
+			assert.Equal(t, "-1", r.URL.Query().Get("direction"))
+
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/models?cursor=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"id":"a","gated":false},{"id":"b","gated":"manual"}]`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"id":"c","gated":true}]`))
+	}))
+	defer server.Close()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	req := &ListModelsRequest{
+		Search:      "bert",
+		PipelineTag: "fill-mask",
+		Tags:        []string{"pytorch", "en"},
+		Sort:        "downloads",
+	}
+
+	t.Run("All pages", func(t *testing.T) {
+		models, err := client.ListModels(context.Background(), req).All()
+		assert.NoError(t, err)
+		assert.Len(t, models, 3)
+		assert.Equal(t, GatedStatusNone, models[0].Gated)
+		assert.Equal(t, GatedStatusManual, models[1].Gated)
+		assert.Equal(t, "c", models[2].ID)
+		assert.Equal(t, GatedStatusAuto, models[2].Gated)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		limited := *req
+		limited.Limit = 1
+
+		models, err := client.ListModels(context.Background(), &limited).All()
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+	})
+}
+
+func TestModelInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/models/org/model/revision/refs%2Fpr%2F1", r.URL.EscapedPath())
+		assert.Equal(t, "true", r.URL.Query().Get("blobs"))
+		assert.Equal(t, "Bearer your-token", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{
+			"id": "org/model",
+			"sha": "abc",
+			"pipeline_tag": "text-classification",
+			"siblings": [{"rfilename": "model.safetensors", "size": 10, "blobId": "def", "lfs": {"size": 10, "sha256": "123", "pointerSize": 130}}],
+			"safetensors": {"parameters": {"F32": 42}, "total": 42}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	info, err := client.ModelInfo(context.Background(), "org/model", "refs/pr/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", info.SHA)
+	assert.Equal(t, "123", info.Siblings[0].LFS.SHA256)
+	assert.Equal(t, int64(42), info.Safetensors.Total)
+}
+
+func TestNextLink(t *testing.T) {
+	assert.Equal(t, "https://hf.co/api/models?cursor=x", nextLink(`<https://hf.co/api/models?cursor=x>; rel="next"`))
+	assert.Equal(t, "", nextLink(`<https://hf.co/api/models?cursor=x>; rel="prev"`))
+	assert.Equal(t, "", nextLink(""))
+}
+
+func TestListRepoFiles(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/datasets/org/data/tree/main/data", r.URL.Path)
+
+		if r.URL.Query().Get("cursor") == "" {
+			assert.Equal(t, "true", r.URL.Query().Get("recursive"))
+
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/datasets/org/data/tree/main/data?recursive=true&cursor=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"type":"directory","oid":"a","size":0,"path":"data/train"}]`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"type":"file","oid":"b","size":10,"path":"data/train/0000.parquet","lfs":{"oid":"c","size":10,"pointerSize":130}}]`))
+	}))
+	defer server.Close()
+
+	client := NewHubClient("", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	files, err := client.ListRepoFiles(context.Background(), &ListRepoFilesRequest{
+		RepoID:    "org/data",
+		RepoType:  RepoTypeDataset,
+		Path:      "data",
+		Recursive: true,
+	}).All()
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "directory", files[0].Type)
+	assert.Equal(t, "c", files[1].LFS.OID)
+
+	_, err = client.ListRepoFiles(context.Background(), &ListRepoFilesRequest{}).All()
+	assert.EqualError(t, err, "repoID is required")
+}
+
+func TestDatasetInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/datasets/org/data", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("blobs"))
+
+		_, _ = w.Write([]byte(`{"id":"org/data","sha":"abc","gated":"auto","tags":["language:en"],"siblings":[{"rfilename":"train.csv","size":10}]}`))
+	}))
+	defer server.Close()
+
+	client := NewHubClient("", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	info, err := client.DatasetInfo(context.Background(), "org/data", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", info.SHA)
+	assert.Equal(t, GatedStatusAuto, info.Gated)
+	assert.Equal(t, "train.csv", info.Siblings[0].RFilename)
+}
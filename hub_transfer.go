@@ -0,0 +1,319 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// errRangeNotSupported is returned if a server ignores the range of a request.
+var errRangeNotSupported = errors.New("range requests are not supported")
+
+// blobTransfer configures the download of a blob.
+type blobTransfer struct {
+	// The size of the chunks of files downloaded with parallel range requests. Zero disables chunking.
+	chunkSize int64
+
+	// The maximum number of chunks of a file downloaded concurrently.
+	chunkConcurrency int
+
+	// Called with the number of bytes written, or with a negative number if written bytes are discarded.
+	// Must be safe for concurrent use.
+	progress func(n int64)
+}
+
+// downloadBlob downloads the file to the blob path. The file is written to a temporary .incomplete file
+// first, which is moved to the blob path after its checksum is verified. Downloads of existing .incomplete
+// files are resumed.
+func (hc *HubClient) downloadBlob(ctx context.Context, metadata *fileMetadata, blob string, transfer blobTransfer) error {
+	if err := os.MkdirAll(filepath.Dir(blob), 0o755); err != nil {
+		return err
+	}
+
+	incomplete := blob + ".incomplete"
+
+	chunked := false
+
+	if transfer.chunkSize > 0 && metadata.size > transfer.chunkSize {
+		// Counts the bytes of the chunks, which are reported again if the file is streamed instead
+		var chunkBytes int64
+
+		chunkTransfer := transfer
+		chunkTransfer.progress = func(n int64) {
+			atomic.AddInt64(&chunkBytes, n)
+			reportProgress(transfer.progress, n)
+		}
+
+		err := hc.downloadChunks(ctx, metadata, incomplete, chunkTransfer)
+		if err != nil && !errors.Is(err, errRangeNotSupported) {
+			return err
+		}
+
+		if n := atomic.LoadInt64(&chunkBytes); err != nil && transfer.progress != nil && n > 0 {
+			transfer.progress(-n)
+		}
+
+		chunked = err == nil
+	}
+
+	if !chunked {
+		if err := hc.downloadStream(ctx, metadata, incomplete, transfer.progress); err != nil {
+			return err
+		}
+	}
+
+	if err := verifyFile(incomplete, metadata.etag, metadata.size); err != nil {
+		_ = os.Remove(incomplete)
+		return err
+	}
+
+	return os.Rename(incomplete, blob)
+}
+
+// downloadStream downloads the file with a single request, resuming from the end of an existing partial file.
+func (hc *HubClient) downloadStream(ctx context.Context, metadata *fileMetadata, path string, progress func(n int64)) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	offset := stat.Size()
+
+	if metadata.size >= 0 && offset > metadata.size {
+		offset = 0
+	}
+
+	if metadata.size > 0 && offset == metadata.size {
+		reportProgress(progress, offset)
+		return nil
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.location, nil)
+	if err != nil {
+		return err
+	}
+
+	if offset > 0 {
+		httpReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := hc.transportFor(metadata.location).send(httpReq)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// The server sends the whole file if it ignores the range
+	if res.StatusCode != http.StatusPartialContent {
+		offset = 0
+	}
+
+	if err := f.Truncate(offset); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reportProgress(progress, offset)
+
+	_, err = io.Copy(&progressWriter{w: f, progress: progress}, res.Body)
+
+	return err
+}
+
+// downloadChunks downloads the file in chunks with concurrent range requests. Each chunk is written to
+// its own part file, so that interrupted downloads are resumed per chunk. The parts are concatenated
+// into the file at the specified path.
+func (hc *HubClient) downloadChunks(ctx context.Context, metadata *fileMetadata, path string, transfer blobTransfer) error {
+	numChunks := int((metadata.size + transfer.chunkSize - 1) / transfer.chunkSize)
+
+	concurrency := transfer.chunkConcurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	partPath := func(i int) string {
+		return fmt.Sprintf("%s.part%d", path, i)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	sem := make(chan struct{}, concurrency)
+
+	for i := 0; i < numChunks; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			start := int64(i) * transfer.chunkSize
+
+			end := start + transfer.chunkSize
+			if end > metadata.size {
+				end = metadata.size
+			}
+
+			if err := hc.downloadRange(ctx, metadata.location, partPath(i), start, end, transfer.progress); err != nil {
+				once.Do(func() {
+					firstErr = err
+
+					cancel()
+				})
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		if errors.Is(firstErr, errRangeNotSupported) {
+			for i := 0; i < numChunks; i++ {
+				_ = os.Remove(partPath(i))
+			}
+		}
+
+		return firstErr
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for i := 0; i < numChunks; i++ {
+		if err := appendFile(f, partPath(i)); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < numChunks; i++ {
+		_ = os.Remove(partPath(i))
+	}
+
+	return nil
+}
+
+// downloadRange downloads the bytes from start to end (exclusive) of the file to the part file,
+// resuming from the end of an existing part file.
+func (hc *HubClient) downloadRange(ctx context.Context, location, path string, start, end int64, progress func(n int64)) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	offset := stat.Size()
+	if offset > end-start {
+		offset = 0
+	}
+
+	reportProgress(progress, offset)
+
+	if offset == end-start {
+		return nil
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start+offset, end-1))
+
+	res, err := hc.transportFor(location).send(httpReq)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusPartialContent {
+		return errRangeNotSupported
+	}
+
+	if err := f.Truncate(offset); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	n, err := io.Copy(&progressWriter{w: f, progress: progress}, io.LimitReader(res.Body, end-start-offset))
+	if err != nil {
+		return err
+	}
+
+	if n != end-start-offset {
+		return fmt.Errorf("incomplete range %d-%d: got %d of %d bytes", start, end-1, n, end-start-offset)
+	}
+
+	return nil
+}
+
+// appendFile appends the content of the file at path to the writer.
+func appendFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+
+	return err
+}
+
+// progressWriter reports the number of bytes written to the underlying writer.
+type progressWriter struct {
+	w        io.Writer
+	progress func(n int64)
+}
+
+// Write writes the bytes to the underlying writer and reports their number.
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	reportProgress(pw.progress, int64(n))
+
+	return n, err
+}
+
+// reportProgress calls the progress function, if set, for a positive number of bytes.
+func reportProgress(progress func(n int64), n int64) {
+	if progress != nil && n > 0 {
+		progress(n)
+	}
+}
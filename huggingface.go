@@ -3,10 +3,13 @@ package huggingface
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -28,11 +31,70 @@ type InferenceClientOptions struct {
 	HTTPClient        HTTPClient
 }
 
-// InferenceClient is a client for performing inference using Hugging Face models.
-type InferenceClient struct {
+// transport bundles the HTTP client and the token shared by all clients.
+type transport struct {
 	httpClient HTTPClient
 	token      string
-	opts       InferenceClientOptions
+}
+
+// send sends the request with the authorization header set.
+// It returns an HTTPError if the server does not respond with a 2xx status code.
+// The caller is responsible for closing the body of the returned response.
+func (t *transport) send(req *http.Request) (*http.Response, error) {
+	if t.token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.token))
+	}
+
+	res, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkResponse(res)
+}
+
+// checkResponse returns the response if its status code is 2xx and an HTTPError otherwise.
+func checkResponse(res *http.Response) (*http.Response, error) {
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		// Responses to HEAD requests report the error in headers only
+		if len(resBody) == 0 {
+			if message := res.Header.Get("X-Error-Message"); message != "" {
+				return nil, &HTTPError{StatusCode: res.StatusCode, Message: message}
+			}
+
+			return nil, &HTTPError{StatusCode: res.StatusCode, Message: http.StatusText(res.StatusCode)}
+		}
+
+		return nil, newHTTPError(res.StatusCode, resBody)
+	}
+
+	return res, nil
+}
+
+// do sends the request and returns the response body or an error if the request fails.
+func (t *transport) do(req *http.Request) ([]byte, error) {
+	res, err := t.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return io.ReadAll(res.Body)
+}
+
+// InferenceClient is a client for performing inference using Hugging Face models.
+// Responses with any 2xx status code are successful, other responses are returned as HTTPError.
+type InferenceClient struct {
+	transport
+	opts InferenceClientOptions
 }
 
 // NewInferenceClient creates a new InferenceClient instance with the specified token.
@@ -51,9 +113,11 @@ func NewInferenceClient(token string, optFns ...func(o *InferenceClientOptions))
 	}
 
 	return &InferenceClient{
-		httpClient: opts.HTTPClient,
-		token:      token,
-		opts:       opts,
+		transport: transport{
+			httpClient: opts.HTTPClient,
+			token:      token,
+		},
+		opts: opts,
 	}
 }
 
@@ -64,6 +128,47 @@ func (ic *InferenceClient) SetModel(model string) {
 // post sends a POST request to the specified model and task with the provided payload.
 // It returns the response body or an error if the request fails.
 func (ic *InferenceClient) post(ctx context.Context, model, task string, payload any) ([]byte, error) {
+	httpReq, err := ic.newPostRequest(ctx, model, task, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+
+	return ic.do(httpReq)
+}
+
+// postForBinary sends a POST request to the specified model and task with the provided payload
+// for tasks that respond with binary data, like audio or images. The accept header requests
+// a format of the response and is not sent if empty.
+// It returns the response body and its content type or an error if the request fails.
+func (ic *InferenceClient) postForBinary(ctx context.Context, model, task, accept string, payload any) ([]byte, string, error) {
+	httpReq, err := ic.newPostRequest(ctx, model, task, payload)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if accept != "" {
+		httpReq.Header.Set("Accept", accept)
+	}
+
+	res, err := ic.send(httpReq)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resBody, res.Header.Get("Content-Type"), nil
+}
+
+// newPostRequest creates a POST request to the specified model and task with the JSON encoded payload.
+func (ic *InferenceClient) newPostRequest(ctx context.Context, model, task string, payload any) (*http.Request, error) {
 	url, err := ic.resolveURL(ctx, model, task)
 	if err != nil {
 		return nil, err
@@ -80,34 +185,46 @@ func (ic *InferenceClient) post(ctx context.Context, model, task string, payload
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
 
-	if ic.token != "" {
-		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ic.token))
+	return httpReq, nil
+}
+
+// postBinary sends a POST request with binary data to the specified model and task.
+// The data is sent as the request body unless parameters are set, in which case it is
+// sent base64 encoded in a JSON payload together with the parameters and options.
+// It returns the response body or an error if the request fails.
+func (ic *InferenceClient) postBinary(ctx context.Context, model, task string, data []byte, contentType string, parameters any, options Options) ([]byte, error) {
+	if parameters != nil && !reflect.ValueOf(parameters).IsZero() {
+		return ic.post(ctx, model, task, map[string]any{
+			"inputs":     base64.StdEncoding.EncodeToString(data),
+			"parameters": parameters,
+			"options":    options,
+		})
 	}
 
-	res, err := ic.httpClient.Do(httpReq)
+	url, err := ic.resolveURL(ctx, model, task)
 	if err != nil {
 		return nil, err
 	}
 
-	defer res.Body.Close()
-
-	resBody, err := io.ReadAll(res.Body)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		errResp := ErrorResponse{}
-		if err := json.Unmarshal(resBody, &errResp); err != nil {
-			return nil, fmt.Errorf("huggingfaces error: %s", resBody)
-		}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Accept", "application/json")
+
+	// Options can not be part of a binary payload and are sent as headers instead
+	if options.UseCache != nil {
+		httpReq.Header.Set("X-Use-Cache", strconv.FormatBool(*options.UseCache))
+	}
 
-		return nil, fmt.Errorf("huggingfaces error: %s", errResp.Error)
+	if options.WaitForModel != nil {
+		httpReq.Header.Set("X-Wait-For-Model", strconv.FormatBool(*options.WaitForModel))
 	}
 
-	return resBody, nil
+	return ic.do(httpReq)
 }
 
 // resolveURL resolves the URL for the specified model and task.
@@ -3,9 +3,18 @@ package huggingface
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -77,6 +86,52 @@ func TestSummarization(t *testing.T) {
 	})
 }
 
+func TestInferenceClientStatusCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		err        string
+	}{
+		{"OK", http.StatusOK, `[{"summary_text":"summary"}]`, ""},
+		{"Accepted", http.StatusAccepted, `[{"summary_text":"summary"}]`, ""},
+		{"No content", http.StatusNoContent, "", "unexpected end of JSON input"},
+		{"Not modified", http.StatusNotModified, "", "huggingfaces error: Not Modified"},
+		{"Service unavailable", http.StatusServiceUnavailable, `{"error":"Model is loading"}`, "huggingfaces error: Model is loading"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+				o.InferenceEndpoint = server.URL
+			})
+
+			// Any 2xx status code is a successful response
+			res, err := client.Summarization(context.Background(), &SummarizationRequest{
+				Inputs: []string{"This is a test input"},
+				Model:  "t5-base",
+			})
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				var httpErr *HTTPError
+				assert.Equal(t, tt.statusCode >= 300, errors.As(err, &httpErr))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "summary", res[0].SummaryText)
+		})
+	}
+}
+
 func TestQuestionAnswering(t *testing.T) {
 	client := NewInferenceClient("your-token")
 
@@ -102,3 +157,630 @@ func TestQuestionAnswering(t *testing.T) {
 		assert.EqualError(t, err, "context is required")
 	})
 }
+
+// sampleWAV returns the bytes of a short mono 16-bit PCM WAV file.
+func sampleWAV() []byte {
+	samples := []int16{0, 1000, 2000, 1000, 0, -1000, -2000, -1000}
+
+	header := struct {
+		RIFF          [4]byte
+		Size          uint32
+		WAVE          [4]byte
+		Fmt           [4]byte
+		FmtSize       uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+		Data          [4]byte
+		DataSize      uint32
+	}{
+		RIFF:          [4]byte{'R', 'I', 'F', 'F'},
+		Size:          uint32(36 + 2*len(samples)),
+		WAVE:          [4]byte{'W', 'A', 'V', 'E'},
+		Fmt:           [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		Format:        1,
+		Channels:      1,
+		SampleRate:    16000,
+		ByteRate:      32000,
+		BlockAlign:    2,
+		BitsPerSample: 16,
+		Data:          [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      uint32(2 * len(samples)),
+	}
+
+	buf := &bytes.Buffer{}
+	for _, v := range []any{header, samples} {
+		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
+			panic(err)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func TestAutomaticSpeechRecognition(t *testing.T) {
+	wav := sampleWAV()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/openai/whisper-tiny", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		if r.Header.Get("Content-Type") == "application/json" {
+			payload := struct {
+				Inputs     []byte         `json:"inputs"`
+				Parameters map[string]any `json:"parameters"`
+			}{}
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			assert.Equal(t, wav, payload.Inputs)
+			assert.Equal(t, "word", payload.Parameters["return_timestamps"])
+
+			_, _ = w.Write([]byte(`{"text":" Hello world","chunks":[{"text":" Hello","timestamp":[0.0,0.5]},{"text":" world","timestamp":[0.5,null]}]}`))
+
+			return
+		}
+
+		assert.Equal(t, "audio/wave", r.Header.Get("Content-Type"))
+		assert.Equal(t, "true", r.Header.Get("X-Wait-For-Model"))
+		assert.Equal(t, wav, body)
+
+		_, _ = w.Write([]byte(`{"text":" Hello world"}`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	t.Run("Binary request", func(t *testing.T) {
+		res, err := client.AutomaticSpeechRecognition(context.Background(), &AutomaticSpeechRecognitionRequest{
+			Inputs: bytes.NewReader(wav),
+			Model:  "openai/whisper-tiny",
+			Options: Options{
+				WaitForModel: PTR(true),
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, " Hello world", res.Text)
+		assert.Empty(t, res.Chunks)
+	})
+
+	t.Run("Request with parameters", func(t *testing.T) {
+		res, err := client.AutomaticSpeechRecognition(context.Background(), &AutomaticSpeechRecognitionRequest{
+			Inputs: bytes.NewReader(wav),
+			Model:  "openai/whisper-tiny",
+			Parameters: AutomaticSpeechRecognitionParameters{
+				ReturnTimestamps: ReturnTimestampsWord,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res.Chunks, 2)
+		assert.Equal(t, [2]float64{0.5, 0}, res.Chunks[1].Timestamp)
+	})
+
+	t.Run("Missing inputs", func(t *testing.T) {
+		_, err := client.AutomaticSpeechRecognition(context.Background(), &AutomaticSpeechRecognitionRequest{})
+		assert.EqualError(t, err, "inputs are required")
+	})
+}
+
+func TestAudioClassification(t *testing.T) {
+	wav := sampleWAV()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/superb/hubert-base-superb-er", r.URL.Path)
+
+		payload := struct {
+			Inputs     []byte         `json:"inputs"`
+			Parameters map[string]any `json:"parameters"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, wav, payload.Inputs)
+		assert.Equal(t, float64(2), payload.Parameters["top_k"])
+
+		_, _ = w.Write([]byte(`[{"label":"neu","score":0.7},{"label":"hap","score":0.2}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.AudioClassification(context.Background(), &AudioClassificationRequest{
+		Inputs:     bytes.NewReader(wav),
+		Parameters: AudioClassificationParameters{TopK: PTR(2)},
+		Model:      "superb/hubert-base-superb-er",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "neu", res[0].Label)
+	assert.Equal(t, 0.7, res[0].Score)
+}
+
+func TestAudioToAudio(t *testing.T) {
+	client := NewInferenceClient("your-token")
+
+	t.Run("Successful Request", func(t *testing.T) {
+		client.httpClient = &mockHTTPClient{Response: []byte(`[{"label":"speech","content-type":"audio/flac","blob":"ZkxhQw=="}]`)}
+
+		res, err := client.AudioToAudio(context.Background(), &AudioToAudioRequest{
+			Inputs: bytes.NewReader(sampleWAV()),
+			Model:  "speechbrain/sepformer-wham",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "speech", res[0].Label)
+		assert.Equal(t, "audio/flac", res[0].ContentType)
+		assert.Equal(t, []byte("fLaC"), res[0].Blob)
+	})
+}
+
+func TestTextToSpeech(t *testing.T) {
+	client := NewInferenceClient("your-token")
+
+	t.Run("Successful Request", func(t *testing.T) {
+		client.httpClient = &mockHTTPClient{Response: sampleWAV()}
+
+		res, err := client.TextToSpeech(context.Background(), &TextToSpeechRequest{
+			Inputs: "Hello world",
+			Model:  "facebook/mms-tts-eng",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 16000, res.SamplingRate)
+
+		audio, err := res.Decode()
+		assert.NoError(t, err)
+		assert.Len(t, audio.Samples, 8)
+	})
+
+	t.Run("Non-WAV audio", func(t *testing.T) {
+		// The STREAMINFO block of a FLAC file with a sample rate of 22050 Hz
+		flac := append([]byte("fLaC\x00\x00\x00\x22\x10\x00\x10\x00\x00\x00\x00\x00\x00\x00\x05\x62\x20"), make([]byte, 16)...)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "audio/wav", r.Header.Get("Accept"))
+
+			w.Header().Set("Content-Type", "audio/flac")
+			_, _ = w.Write(flac)
+		}))
+		defer server.Close()
+
+		flacClient := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+			o.InferenceEndpoint = server.URL
+		})
+
+		res, err := flacClient.TextToSpeech(context.Background(), &TextToSpeechRequest{
+			Inputs: "Hello world",
+			Model:  "espnet/kan-bayashi_ljspeech_vits",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "audio/flac", res.ContentType)
+		assert.Equal(t, 22050, res.SamplingRate)
+
+		_, err = res.Decode()
+		assert.EqualError(t, err, "decoding audio/flac audio is not supported")
+	})
+
+	t.Run("Empty Inputs", func(t *testing.T) {
+		_, err := client.TextToSpeech(context.Background(), &TextToSpeechRequest{})
+		assert.EqualError(t, err, "inputs are required")
+	})
+}
+
+func TestImageClassification(t *testing.T) {
+	img := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(img, image.NewGray(image.Rect(0, 0, 2, 2))))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/google/vit-base-patch16-224", r.URL.Path)
+		assert.Equal(t, "Bearer your-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		if r.Header.Get("Content-Type") == "application/json" {
+			payload := struct {
+				Inputs     []byte                        `json:"inputs"`
+				Parameters ImageClassificationParameters `json:"parameters"`
+			}{}
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			assert.Equal(t, img.Bytes(), payload.Inputs)
+			assert.Equal(t, 1, *payload.Parameters.TopK)
+			assert.Equal(t, "softmax", payload.Parameters.FunctionToApply)
+
+			_, _ = w.Write([]byte(`[{"label":"tabby cat","score":0.8}]`))
+
+			return
+		}
+
+		assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+		assert.Equal(t, "true", r.Header.Get("X-Wait-For-Model"))
+		assert.Equal(t, img.Bytes(), body)
+
+		_, _ = w.Write([]byte(`[{"label":"tabby cat","score":0.8},{"label":"tiger cat","score":0.15}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	t.Run("Binary payload", func(t *testing.T) {
+		res, err := client.ImageClassification(context.Background(), &ImageClassificationRequest{
+			Inputs:  ImageFromBytes(img.Bytes()),
+			Options: Options{WaitForModel: PTR(true)},
+			Model:   "google/vit-base-patch16-224",
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "tabby cat", res[0].Label)
+		assert.Equal(t, 0.8, res[0].Score)
+		assert.Equal(t, "tiger cat", res[1].Label)
+		assert.Equal(t, 0.15, res[1].Score)
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		res, err := client.ImageClassification(context.Background(), &ImageClassificationRequest{
+			Inputs: ImageFromBytes(img.Bytes()),
+			Parameters: ImageClassificationParameters{
+				TopK:            PTR(1),
+				FunctionToApply: "softmax",
+			},
+			Model: "google/vit-base-patch16-224",
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "tabby cat", res[0].Label)
+	})
+
+	t.Run("Missing inputs", func(t *testing.T) {
+		_, err := client.ImageClassification(context.Background(), &ImageClassificationRequest{})
+		assert.EqualError(t, err, "inputs are required")
+	})
+}
+
+func TestZeroShotImageClassification(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	imgPNG := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(imgPNG, img))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cat.png" {
+			assert.Empty(t, r.Header.Get("Authorization"))
+
+			_, _ = w.Write(imgPNG.Bytes())
+
+			return
+		}
+
+		assert.Equal(t, "Bearer your-token", r.Header.Get("Authorization"))
+
+		payload := struct {
+			Inputs     []byte                                `json:"inputs"`
+			Parameters ZeroShotImageClassificationParameters `json:"parameters"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, imgPNG.Bytes(), payload.Inputs)
+		assert.Equal(t, []string{"cat", "dog"}, payload.Parameters.CandidateLabels)
+
+		_, _ = w.Write([]byte(`[{"label":"cat","score":0.9},{"label":"dog","score":0.1}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	for name, input := range map[string]ImageInput{
+		"Image": ImageFromImage(img),
+		"Bytes": ImageFromBytes(imgPNG.Bytes()),
+		"URL":   ImageFromURL(server.URL + "/cat.png"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := client.ZeroShotImageClassification(context.Background(), &ZeroShotImageClassificationRequest{
+				Inputs: input,
+				Parameters: ZeroShotImageClassificationParameters{
+					CandidateLabels: []string{"cat", "dog"},
+				},
+				Model: "openai/clip-vit-large-patch14",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "cat", res[0].Label)
+		})
+	}
+
+	t.Run("Missing inputs", func(t *testing.T) {
+		_, err := client.ZeroShotImageClassification(context.Background(), &ZeroShotImageClassificationRequest{
+			Parameters: ZeroShotImageClassificationParameters{
+				CandidateLabels: []string{"cat", "dog"},
+			},
+		})
+		assert.EqualError(t, err, "inputs are required")
+	})
+}
+
+func TestImageSegmentation(t *testing.T) {
+	mask := image.NewGray(image.Rect(0, 0, 4, 4))
+	mask.SetGray(1, 2, color.Gray{Y: 255})
+	mask.SetGray(2, 3, color.Gray{Y: 255})
+
+	maskPNG := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(maskPNG, mask))
+
+	client := NewInferenceClient("your-token")
+	client.httpClient = &mockHTTPClient{Response: []byte(fmt.Sprintf(`[{"label":"cat","score":null,"mask":"%s"}]`, base64.StdEncoding.EncodeToString(maskPNG.Bytes())))}
+
+	res, err := client.ImageSegmentation(context.Background(), &ImageSegmentationRequest{
+		Inputs: ImageFromImage(image.NewRGBA(image.Rect(0, 0, 4, 4))),
+		Model:  "nvidia/segformer-b0-finetuned-ade-512-512",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cat", res[0].Label)
+	assert.Equal(t, 2, res[0].Area())
+	assert.Equal(t, image.Rect(1, 2, 3, 4), res[0].Bounds())
+
+	overlay := OverlayMasks(image.NewRGBA(image.Rect(0, 0, 4, 4)), res, 1)
+	assert.Equal(t, labelColor("cat").R, overlay.RGBAAt(1, 2).R)
+	assert.Equal(t, uint8(0), overlay.RGBAAt(0, 0).R)
+}
+
+func TestTextToImageBatch(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	imgPNG := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(imgPNG, img))
+
+	seeds := []int64{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := TextToImageRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "An astronaut riding a horse", req.Inputs)
+
+		seeds = append(seeds, *req.Parameters.Seed)
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(imgPNG.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.TextToImageBatch(context.Background(), &TextToImageRequest{
+		Inputs: "An astronaut riding a horse",
+		Model:  "stabilityai/stable-diffusion-2",
+	}, []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, seeds)
+	assert.Equal(t, image.Rect(0, 0, 2, 2), res[1].Image.Bounds())
+
+	dir := t.TempDir()
+
+	paths, err := WriteTextToImageResults(dir, res)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "image-0-seed-1.png"), filepath.Join(dir, "image-1-seed-2.png")}, paths)
+}
+
+func TestTextToImageUndecodableFormat(t *testing.T) {
+	webp := []byte("RIFF\x1a\x00\x00\x00WEBPVP8L\x0d\x00\x00\x00")
+
+	client := NewInferenceClient("your-token")
+	client.httpClient = &mockHTTPClient{Response: webp}
+
+	res, err := client.TextToImage(context.Background(), &TextToImageRequest{
+		Inputs: "An astronaut riding a horse",
+		Model:  "stabilityai/stable-diffusion-2",
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, res.Image)
+	assert.Equal(t, webp, res.Data)
+	assert.Equal(t, "image/webp", res.ContentType)
+}
+
+func TestTextToImageCorruptImage(t *testing.T) {
+	client := NewInferenceClient("your-token")
+	client.httpClient = &mockHTTPClient{Response: []byte("\x89PNG\r\n\x1a\ntruncated")}
+
+	_, err := client.TextToImage(context.Background(), &TextToImageRequest{
+		Inputs: "An astronaut riding a horse",
+		Model:  "stabilityai/stable-diffusion-2",
+	})
+	assert.Error(t, err)
+}
+
+func TestImageToText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/Salesforce/blip-image-captioning-base", r.URL.Path)
+
+		payload := struct {
+			Inputs     []byte                `json:"inputs"`
+			Parameters ImageToTextParameters `json:"parameters"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []byte("image"), payload.Inputs)
+		assert.Equal(t, 20, *payload.Parameters.MaxNewTokens)
+		assert.Equal(t, 3, *payload.Parameters.GenerateKwargs.NumBeams)
+
+		_, _ = w.Write([]byte(`[{"generated_text":"a cat sitting on a couch"}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.ImageToText(context.Background(), &ImageToTextRequest{
+		Inputs: ImageFromBytes([]byte("image")),
+		Parameters: ImageToTextParameters{
+			MaxNewTokens:   PTR(20),
+			GenerateKwargs: &ImageToTextGenerateKwargs{NumBeams: PTR(3)},
+		},
+		Model: "Salesforce/blip-image-captioning-base",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "a cat sitting on a couch", res[0].GeneratedText)
+
+	_, err = client.ImageToText(context.Background(), &ImageToTextRequest{})
+	assert.EqualError(t, err, "inputs are required")
+}
+
+func TestImageToImage(t *testing.T) {
+	output := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(output, image.NewGray(image.Rect(0, 0, 3, 2))))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/timbrooks/instruct-pix2pix", r.URL.Path)
+
+		payload := struct {
+			Inputs     []byte                 `json:"inputs"`
+			Parameters ImageToImageParameters `json:"parameters"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []byte("image"), payload.Inputs)
+		assert.Equal(t, "Turn it into a painting", payload.Parameters.Prompt)
+		assert.Equal(t, &ImageToImageTargetSize{Width: 3, Height: 2}, payload.Parameters.TargetSize)
+
+		_, _ = w.Write(output.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.ImageToImage(context.Background(), &ImageToImageRequest{
+		Inputs: ImageFromBytes([]byte("image")),
+		Parameters: ImageToImageParameters{
+			Prompt:     "Turn it into a painting",
+			TargetSize: &ImageToImageTargetSize{Width: 3, Height: 2},
+		},
+		Model: "timbrooks/instruct-pix2pix",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 3, 2), res.Image.Bounds())
+	assert.Equal(t, output.Bytes(), res.Data)
+	assert.Equal(t, "image/png", res.ContentType)
+}
+
+func TestDepthEstimation(t *testing.T) {
+	client := NewInferenceClient("your-token")
+	client.httpClient = &mockHTTPClient{Response: []byte(`{"predicted_depth":[[[1.0,2.0],[3.0,5.0]]]}`)}
+
+	res, err := client.DepthEstimation(context.Background(), &DepthEstimationRequest{
+		Inputs: ImageFromImage(image.NewRGBA(image.Rect(0, 0, 2, 2))),
+		Model:  "Intel/dpt-large",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}, {3, 5}}, res.PredictedDepth)
+	assert.Equal(t, uint8(0), res.Depth.GrayAt(0, 0).Y)
+	assert.Equal(t, uint8(255), res.Depth.GrayAt(1, 1).Y)
+}
+
+func TestDocumentQuestionAnswering(t *testing.T) {
+	client := NewInferenceClient("your-token")
+
+	t.Run("Missing question input", func(t *testing.T) {
+		_, err := client.DocumentQuestionAnswering(context.Background(), &DocumentQuestionAnsweringRequest{
+			Inputs: DocumentQuestionAnsweringInputs{
+				Image: ImageFromBytes([]byte("invoice")),
+			},
+		})
+		assert.EqualError(t, err, "question is required")
+	})
+
+	t.Run("Word boxes", func(t *testing.T) {
+		b, err := json.Marshal(DocumentQuestionAnsweringParameters{
+			WordBoxes: []WordBox{{Word: "Total", Box: [4]int{10, 20, 30, 40}}},
+		})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"word_boxes":[["Total",[10,20,30,40]]]}`, string(b))
+	})
+
+	t.Run("Successful Request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/models/impira/layoutlm-document-qa", r.URL.Path)
+
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{
+				"inputs": {"image": "aW52b2ljZQ==", "question": "What is the total?"},
+				"parameters": {"top_k": 1, "word_boxes": [["Total", [10, 20, 30, 40]], ["$42", [40, 20, 60, 40]]]},
+				"options": {}
+			}`, string(body))
+
+			_, _ = w.Write([]byte(`[{"answer":"$42","score":0.98,"start":1,"end":1}]`))
+		}))
+		defer server.Close()
+
+		client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+			o.InferenceEndpoint = server.URL
+		})
+
+		res, err := client.DocumentQuestionAnswering(context.Background(), &DocumentQuestionAnsweringRequest{
+			Inputs: DocumentQuestionAnsweringInputs{
+				Image:    ImageFromBytes([]byte("invoice")),
+				Question: "What is the total?",
+			},
+			Parameters: DocumentQuestionAnsweringParameters{
+				TopK: PTR(1),
+				WordBoxes: []WordBox{
+					{Word: "Total", Box: [4]int{10, 20, 30, 40}},
+					{Word: "$42", Box: [4]int{40, 20, 60, 40}},
+				},
+			},
+			Model: "impira/layoutlm-document-qa",
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "$42", res[0].Answer)
+		assert.Equal(t, 0.98, res[0].Score)
+		assert.Equal(t, 1, res[0].Start)
+	})
+}
+
+func TestVisualQuestionAnswering(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/dandelin/vilt-b32-finetuned-vqa", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"inputs": {"image": "aW1hZ2U=", "question": "What animal is this?"},
+			"parameters": {"top_k": 2},
+			"options": {"wait_for_model": true}
+		}`, string(body))
+
+		_, _ = w.Write([]byte(`[{"answer":"cat","score":0.9},{"answer":"dog","score":0.05}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.VisualQuestionAnswering(context.Background(), &VisualQuestionAnsweringRequest{
+		Inputs: VisualQuestionAnsweringInputs{
+			Image:    ImageFromBytes([]byte("image")),
+			Question: "What animal is this?",
+		},
+		Parameters: VisualQuestionAnsweringParameters{TopK: PTR(2)},
+		Options:    Options{WaitForModel: PTR(true)},
+		Model:      "dandelin/vilt-b32-finetuned-vqa",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "cat", res[0].Answer)
+	assert.Equal(t, 0.9, res[0].Score)
+
+	_, err = client.VisualQuestionAnswering(context.Background(), &VisualQuestionAnsweringRequest{})
+	assert.EqualError(t, err, "question is required")
+}
@@ -0,0 +1,56 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Used with ImageClassificationRequest
+type ImageClassificationParameters struct {
+	// (Default: None). Integer. The number of top labels to return.
+	TopK *int `json:"top_k,omitempty"`
+
+	// (Default: None). The function applied to the model outputs to retrieve the scores,
+	// one of sigmoid, softmax or none.
+	FunctionToApply string `json:"function_to_apply,omitempty"`
+}
+
+// Request structure for the image classification endpoint
+type ImageClassificationRequest struct {
+	// (Required) The image to classify.
+	Inputs     ImageInput
+	Parameters ImageClassificationParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the image classification endpoint
+type ImageClassificationResponse []struct {
+	// The label for the class (model specific).
+	Label string `json:"label"`
+
+	// A float that represents how likely it is that the image belongs to this class.
+	Score float64 `json:"score"`
+}
+
+// ImageClassification performs image classification using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided image.
+// The response contains the labels with their scores or an error if the request fails.
+func (ic *InferenceClient) ImageClassification(ctx context.Context, req *ImageClassificationRequest) (ImageClassificationResponse, error) {
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "image-classification", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	imageClassificationResponse := ImageClassificationResponse{}
+	if err := json.Unmarshal(body, &imageClassificationResponse); err != nil {
+		return nil, err
+	}
+
+	return imageClassificationResponse, nil
+}
@@ -0,0 +1,193 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Used with ImageSegmentationRequest
+type ImageSegmentationParameters struct {
+	// (Default: None). The segmentation subtask, one of instance, panoptic or semantic.
+	Subtask string `json:"subtask,omitempty"`
+
+	// (Default: 0.9). Float. The probability threshold to filter out predicted masks.
+	Threshold *float64 `json:"threshold,omitempty"`
+
+	// (Default: 0.5). Float. The threshold to use when turning the predicted masks into binary values.
+	MaskThreshold *float64 `json:"mask_threshold,omitempty"`
+
+	// (Default: 0.8). Float. The mask overlap threshold to eliminate small, disconnected segments.
+	OverlapMaskAreaThreshold *float64 `json:"overlap_mask_area_threshold,omitempty"`
+}
+
+// Request structure for the image segmentation endpoint
+type ImageSegmentationRequest struct {
+	// (Required) The image to segment.
+	Inputs     ImageInput
+	Parameters ImageSegmentationParameters
+	Options    Options
+	Model      string
+}
+
+// ImageSegment represents a segment of an image.
+type ImageSegment struct {
+	// The label of the segment (model specific).
+	Label string
+
+	// A float that represents how likely it is that the segment belongs to the label.
+	// Semantic segmentation models do not return scores.
+	Score float64
+
+	// The mask of the segment, where non-zero pixels belong to the segment.
+	Mask *image.Gray
+}
+
+// UnmarshalJSON decodes the segment and its base64 encoded PNG mask.
+func (s *ImageSegment) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Label string   `json:"label"`
+		Score *float64 `json:"score"`
+		Mask  string   `json:"mask"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	s.Label = raw.Label
+
+	if raw.Score != nil {
+		s.Score = *raw.Score
+	}
+
+	maskData, err := base64.StdEncoding.DecodeString(raw.Mask)
+	if err != nil {
+		return err
+	}
+
+	mask, err := decodeGray(maskData)
+	if err != nil {
+		return err
+	}
+
+	s.Mask = mask
+
+	return nil
+}
+
+// Area returns the number of pixels of the segment.
+func (s *ImageSegment) Area() int {
+	area := 0
+
+	for _, v := range s.Mask.Pix {
+		if v != 0 {
+			area++
+		}
+	}
+
+	return area
+}
+
+// Bounds returns the bounding box of the segment, or an empty rectangle if the mask is empty.
+func (s *ImageSegment) Bounds() image.Rectangle {
+	bounds := image.Rectangle{}
+	b := s.Mask.Bounds()
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if s.Mask.GrayAt(x, y).Y != 0 {
+				bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return bounds
+}
+
+// Response structure for the image segmentation endpoint
+type ImageSegmentationResponse []ImageSegment
+
+// ImageSegmentation performs image segmentation using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided image.
+// The response contains the segments with decoded masks or an error if the request fails.
+func (ic *InferenceClient) ImageSegmentation(ctx context.Context, req *ImageSegmentationRequest) (ImageSegmentationResponse, error) {
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "image-segmentation", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	imageSegmentationResponse := ImageSegmentationResponse{}
+	if err := json.Unmarshal(body, &imageSegmentationResponse); err != nil {
+		return nil, err
+	}
+
+	return imageSegmentationResponse, nil
+}
+
+// OverlayMasks blends the masks of the segments onto a copy of the source image. Each label is drawn
+// in its own color with the specified opacity between 0 and 1.
+func OverlayMasks(src image.Image, segments []ImageSegment, alpha float64) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	draw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	for _, segment := range segments {
+		c := labelColor(segment.Label)
+		mb := segment.Mask.Bounds()
+
+		for y := mb.Min.Y; y < mb.Max.Y; y++ {
+			for x := mb.Min.X; x < mb.Max.X; x++ {
+				// The mask is aligned with the top-left corner of the image
+				px, py := x-mb.Min.X+dst.Rect.Min.X, y-mb.Min.Y+dst.Rect.Min.Y
+				if !image.Pt(px, py).In(dst.Rect) {
+					continue
+				}
+
+				a := alpha * float64(segment.Mask.GrayAt(x, y).Y) / 255
+				if a == 0 {
+					continue
+				}
+
+				o := dst.RGBAAt(px, py)
+				dst.SetRGBA(px, py, color.RGBA{
+					R: blend(o.R, c.R, a),
+					G: blend(o.G, c.G, a),
+					B: blend(o.B, c.B, a),
+					A: o.A,
+				})
+			}
+		}
+	}
+
+	return dst
+}
+
+// blend mixes the color channel values with the specified weight of the second value.
+func blend(a, b uint8, weight float64) uint8 {
+	return uint8(float64(a)*(1-weight) + float64(b)*weight)
+}
+
+// decodeGray decodes an encoded image and converts it to grayscale if necessary.
+func decodeGray(data []byte) (*image.Gray, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if gray, ok := img.(*image.Gray); ok {
+		return gray, nil
+	}
+
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	return gray, nil
+}
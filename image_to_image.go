@@ -0,0 +1,95 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+)
+
+// Used with ImageToImageParameters
+type ImageToImageTargetSize struct {
+	// The width in pixels of the output image.
+	Width int `json:"width"`
+
+	// The height in pixels of the output image.
+	Height int `json:"height"`
+}
+
+// Used with ImageToImageRequest
+type ImageToImageParameters struct {
+	// (Default: None). The text prompt to guide the transformation.
+	Prompt string `json:"prompt,omitempty"`
+
+	// (Default: None). A prompt to guide what should not be included in the image.
+	NegativePrompt string `json:"negative_prompt,omitempty"`
+
+	// (Default: None). Float. A higher guidance scale encourages images that are closely linked to
+	// the prompt at the expense of lower image quality.
+	GuidanceScale *float64 `json:"guidance_scale,omitempty"`
+
+	// (Default: None). Integer. The number of denoising steps. More steps usually lead to a higher
+	// quality image at the expense of slower inference.
+	NumInferenceSteps *int `json:"num_inference_steps,omitempty"`
+
+	// (Default: None). Float (0.0-1.0). How much the input image is transformed. 1 ignores the input image.
+	Strength *float64 `json:"strength,omitempty"`
+
+	// (Default: None). The size of the output image.
+	TargetSize *ImageToImageTargetSize `json:"target_size,omitempty"`
+}
+
+// Request structure for the image-to-image endpoint
+type ImageToImageRequest struct {
+	// (Required) The image to transform.
+	Inputs     ImageInput
+	Parameters ImageToImageParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the image-to-image endpoint
+type ImageToImageResponse struct {
+	// The transformed image.
+	Image image.Image
+
+	// The encoded image as returned by the model.
+	Data []byte
+
+	// The content type of the encoded image, e.g. image/png.
+	ContentType string
+}
+
+// ImageToImage transforms an image, guided by a text prompt, using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the base64 encoded image
+// and the parameters. The response contains the transformed image or an error if the request fails.
+func (ic *InferenceClient) ImageToImage(ctx context.Context, req *ImageToImageRequest) (*ImageToImageResponse, error) {
+	data, _, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := ic.postForBinary(ctx, req.Model, "image-to-image", "", map[string]any{
+		"inputs":     base64.StdEncoding.EncodeToString(data),
+		"parameters": req.Parameters,
+		"options":    req.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType == "" {
+		contentType = "image/" + format
+	}
+
+	return &ImageToImageResponse{
+		Image:       img,
+		Data:        body,
+		ContentType: contentType,
+	}, nil
+}
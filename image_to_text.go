@@ -0,0 +1,73 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Used with ImageToTextParameters
+type ImageToTextGenerateKwargs struct {
+	// (Default: None). Bool. Whether to use sampling instead of greedy decoding.
+	DoSample *bool `json:"do_sample,omitempty"`
+
+	// (Default: None). Integer. The minimum number of tokens to generate.
+	MinNewTokens *int `json:"min_new_tokens,omitempty"`
+
+	// (Default: None). Integer. The number of beams for beam search.
+	NumBeams *int `json:"num_beams,omitempty"`
+
+	// (Default: 1.0). Float. The temperature of the sampling operation.
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// (Default: None). Integer to define the top tokens considered within the sample operation.
+	TopK *int `json:"top_k,omitempty"`
+
+	// (Default: None). Float to define the tokens that are within the sample operation.
+	TopP *float64 `json:"top_p,omitempty"`
+}
+
+// Used with ImageToTextRequest
+type ImageToTextParameters struct {
+	// (Default: None). Integer. The maximum number of tokens to generate.
+	MaxNewTokens *int `json:"max_new_tokens,omitempty"`
+
+	// Parameters passed to the generate method of the model.
+	GenerateKwargs *ImageToTextGenerateKwargs `json:"generate_kwargs,omitempty"`
+}
+
+// Request structure for the image-to-text endpoint
+type ImageToTextRequest struct {
+	// (Required) The image to caption.
+	Inputs     ImageInput
+	Parameters ImageToTextParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the image-to-text endpoint
+type ImageToTextResponse []struct {
+	// The generated caption.
+	GeneratedText string `json:"generated_text"`
+}
+
+// ImageToText generates a caption for an image using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided image.
+// The response contains the generated text or an error if the request fails.
+func (ic *InferenceClient) ImageToText(ctx context.Context, req *ImageToTextRequest) (ImageToTextResponse, error) {
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "image-to-text", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	imageToTextResponse := ImageToTextResponse{}
+	if err := json.Unmarshal(body, &imageToTextResponse); err != nil {
+		return nil, err
+	}
+
+	return imageToTextResponse, nil
+}
@@ -0,0 +1,161 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageInput represents an image sent to vision tasks.
+// Use ImageFromBytes, ImageFromFile, ImageFromURL or ImageFromImage to create one.
+type ImageInput interface {
+	// read returns the encoded image and its content type.
+	read(ctx context.Context, httpClient HTTPClient) ([]byte, string, error)
+}
+
+// ImageFromBytes creates an ImageInput from an encoded image, e.g. the content of a PNG or JPEG file.
+func ImageFromBytes(data []byte) ImageInput {
+	return bytesImageInput(data)
+}
+
+// ImageFromFile creates an ImageInput from the named image file.
+func ImageFromFile(name string) ImageInput {
+	return fileImageInput(name)
+}
+
+// ImageFromURL creates an ImageInput from an image that is downloaded from the URL.
+// The download does not include the token of the client.
+func ImageFromURL(url string) ImageInput {
+	return urlImageInput(url)
+}
+
+// ImageFromImage creates an ImageInput from an image, which is sent PNG encoded.
+func ImageFromImage(img image.Image) ImageInput {
+	return imageImageInput{img}
+}
+
+type bytesImageInput []byte
+
+func (i bytesImageInput) read(_ context.Context, _ HTTPClient) ([]byte, string, error) {
+	return i, http.DetectContentType(i), nil
+}
+
+type fileImageInput string
+
+func (i fileImageInput) read(_ context.Context, _ HTTPClient) ([]byte, string, error) {
+	data, err := os.ReadFile(string(i))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, detectContentType(data, string(i)), nil
+}
+
+type urlImageInput string
+
+func (i urlImageInput) read(ctx context.Context, httpClient HTTPClient) ([]byte, string, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, string(i), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	t := transport{httpClient: httpClient}
+
+	data, err := t.do(httpReq)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, http.DetectContentType(data), nil
+}
+
+type imageImageInput struct {
+	img image.Image
+}
+
+func (i imageImageInput) read(_ context.Context, _ HTTPClient) ([]byte, string, error) {
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, i.img); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), "image/png", nil
+}
+
+// readImageInput reads the encoded image and its content type from the input.
+func (ic *InferenceClient) readImageInput(ctx context.Context, input ImageInput) ([]byte, string, error) {
+	if input == nil {
+		return nil, "", errors.New("inputs are required")
+	}
+
+	data, contentType, err := input.read(ctx, ic.httpClient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(data) == 0 {
+		return nil, "", errors.New("inputs are required")
+	}
+
+	return data, contentType, nil
+}
+
+// readBinaryInput reads binary input data from the reader or, if the reader is nil, from the file.
+func readBinaryInput(r io.Reader, filename string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch {
+	case r != nil:
+		data, err = io.ReadAll(r)
+	case filename != "":
+		data, err = os.ReadFile(filename)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	return data, nil
+}
+
+// readBinaryInputWithContentType reads binary input data like readBinaryInput and detects
+// its content type unless the content type is specified.
+func readBinaryInputWithContentType(r io.Reader, filename, contentType string) ([]byte, string, error) {
+	data, err := readBinaryInput(r, filename)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if contentType == "" {
+		contentType = detectContentType(data, filename)
+	}
+
+	return data, contentType, nil
+}
+
+// detectContentType returns the content type of the data. It uses the extension of the
+// filename if it is known and sniffs the data otherwise.
+func detectContentType(data []byte, filename string) string {
+	if filename != "" {
+		if contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))); contentType != "" {
+			return contentType
+		}
+	}
+
+	return http.DetectContentType(data)
+}
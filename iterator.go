@@ -0,0 +1,141 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Iterator iterates over the items of a paginated listing. Pages are fetched lazily when
+// the items of the previous page are consumed.
+//
+//	it := hc.ListModels(ctx, &ListModelsRequest{Search: "bert"})
+//	for it.Next() {
+//		fmt.Println(it.Value().ID)
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   func(ctx context.Context, url string) ([]T, string, error)
+	nextURL string
+	limit   int
+	count   int
+	items   []T
+	current T
+	err     error
+}
+
+// newIterator creates an iterator starting at the specified URL. The fetch function returns the
+// items of a page and the URL of the next page, which is empty for the last page. A positive limit
+// stops the iteration after limit items.
+func newIterator[T any](ctx context.Context, url string, limit int, fetch func(ctx context.Context, url string) ([]T, string, error)) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:     ctx,
+		fetch:   fetch,
+		nextURL: url,
+		limit:   limit,
+	}
+}
+
+// errIterator creates an iterator without items that fails with the specified error.
+func errIterator[T any](err error) *Iterator[T] {
+	return &Iterator[T]{err: err}
+}
+
+// Next advances the iterator to the next item, which is then available through Value.
+// It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || (it.limit > 0 && it.count >= it.limit) {
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.nextURL == "" {
+			return false
+		}
+
+		items, nextURL, err := it.fetch(it.ctx, it.nextURL)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items, it.nextURL = items, nextURL
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+	it.count++
+
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns all remaining items.
+func (it *Iterator[T]) All() ([]T, error) {
+	items := []T{}
+
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+
+	return items, it.Err()
+}
+
+// fetchJSONPage returns a fetch function that decodes a page of items from the JSON array
+// returned by a GET request and follows the next link of the Link header.
+func fetchJSONPage[T any](t *transport) func(ctx context.Context, url string) ([]T, string, error) {
+	return func(ctx context.Context, url string) ([]T, string, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, "", err
+		}
+
+		httpReq.Header.Set("Accept", "application/json")
+
+		res, err := t.send(httpReq)
+		if err != nil {
+			return nil, "", err
+		}
+
+		defer res.Body.Close()
+
+		items := []T{}
+		if err := json.NewDecoder(res.Body).Decode(&items); err != nil {
+			return nil, "", err
+		}
+
+		return items, nextLink(res.Header.Get("Link")), nil
+	}
+}
+
+// nextLink returns the URL of the next page from a Link header, e.g.
+// <https://huggingface.co/api/models?cursor=abc>; rel="next".
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		for _, param := range parts[1:] {
+			if strings.ReplaceAll(strings.TrimSpace(param), " ", "") == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+
+	return ""
+}
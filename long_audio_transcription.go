@@ -0,0 +1,411 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// LongAudioTranscriptionRequest represents a request for the transcription of long audio.
+type LongAudioTranscriptionRequest struct {
+	// (Required) The audio to transcribe.
+	Audio *Audio
+
+	// (Default: 30s). The length of the chunks the audio is split into.
+	ChunkLength time.Duration
+
+	// (Default: 2s). The overlap between consecutive chunks, which avoids cutting words in half.
+	Overlap time.Duration
+
+	// (Default: false). Whether chunks are cut at the quietest frame near their end instead of at
+	// fixed positions. Chunks cut at silence do not overlap.
+	SplitOnSilence bool
+
+	// (Default: 0.01). The RMS energy below which a frame is considered silent.
+	SilenceThreshold float64
+
+	// (Default: 4). The maximum number of chunks transcribed concurrently.
+	MaxConcurrency int
+
+	// Parameters used for the transcription of each chunk. Segment level timestamps are requested
+	// if ReturnTimestamps is empty.
+	Parameters AutomaticSpeechRecognitionParameters
+	Options    Options
+	Model      string
+}
+
+// TranscriptionSegment represents a segment of a transcription.
+type TranscriptionSegment struct {
+	// The start of the segment within the audio.
+	Start time.Duration
+
+	// The end of the segment within the audio.
+	End time.Duration
+
+	// The text of the segment.
+	Text string
+}
+
+// LongAudioTranscriptionResponse represents the transcription of long audio.
+type LongAudioTranscriptionResponse struct {
+	// The transcription of the audio.
+	Text string
+
+	// The segments of the transcription with timestamps relative to the start of the audio.
+	Segments []TranscriptionSegment
+}
+
+// SRT returns the segments of the transcription in the SubRip subtitle format.
+func (r *LongAudioTranscriptionResponse) SRT() string {
+	var sb strings.Builder
+
+	for i, segment := range r.Segments {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n", i+1, formatSubtitleTimestamp(segment.Start, ","), formatSubtitleTimestamp(segment.End, ","), segment.Text)
+	}
+
+	return sb.String()
+}
+
+// WebVTT returns the segments of the transcription in the WebVTT subtitle format.
+func (r *LongAudioTranscriptionResponse) WebVTT() string {
+	var sb strings.Builder
+
+	sb.WriteString("WEBVTT\n\n")
+
+	for _, segment := range r.Segments {
+		fmt.Fprintf(&sb, "%s --> %s\n%s\n\n", formatSubtitleTimestamp(segment.Start, "."), formatSubtitleTimestamp(segment.End, "."), segment.Text)
+	}
+
+	return sb.String()
+}
+
+// TranscribeLongAudio transcribes audio that is too long for a single request. The audio is split into
+// overlapping chunks that are transcribed concurrently with AutomaticSpeechRecognition. The transcriptions
+// of the chunks are merged with timestamps relative to the start of the audio and the text of the overlaps
+// is de-duplicated.
+func (ic *InferenceClient) TranscribeLongAudio(ctx context.Context, req *LongAudioTranscriptionRequest) (*LongAudioTranscriptionResponse, error) {
+	if req.Audio == nil || len(req.Audio.Samples) == 0 {
+		return nil, errors.New("audio is required")
+	}
+
+	if req.Audio.SampleRate <= 0 {
+		return nil, errors.New("sample rate must be positive")
+	}
+
+	chunkLength := req.ChunkLength
+	if chunkLength == 0 {
+		chunkLength = 30 * time.Second
+	}
+
+	overlap := req.Overlap
+	if overlap == 0 {
+		overlap = 2 * time.Second
+	}
+
+	if overlap < 0 {
+		return nil, errors.New("overlap must not be negative")
+	}
+
+	if overlap >= chunkLength/2 {
+		return nil, errors.New("overlap must be shorter than half the chunk length")
+	}
+
+	silenceThreshold := req.SilenceThreshold
+	if silenceThreshold == 0 {
+		silenceThreshold = 0.01
+	}
+
+	maxConcurrency := req.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = 4
+	}
+
+	parameters := req.Parameters
+	if parameters.ReturnTimestamps == "" {
+		parameters.ReturnTimestamps = ReturnTimestampsSegment
+	}
+
+	chunks, err := planAudioChunks(req.Audio, chunkLength, overlap, req.SplitOnSilence, silenceThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	transcriptions := make([][]TranscriptionSegment, len(chunks))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxConcurrency)
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+
+		go func(i int, chunk audioChunk) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			segments, err := ic.transcribeAudioChunk(ctx, req, parameters, chunk)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("chunk %d: %w", i, err)
+
+					cancel()
+				})
+
+				return
+			}
+
+			transcriptions[i] = segments
+		}(i, chunk)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	// Chunks are skipped if the context of the caller is canceled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	segments := mergeTranscriptions(req.Audio, chunks, transcriptions)
+
+	texts := make([]string, len(segments))
+	for i, segment := range segments {
+		texts[i] = segment.Text
+	}
+
+	return &LongAudioTranscriptionResponse{
+		Text:     strings.Join(texts, " "),
+		Segments: segments,
+	}, nil
+}
+
+// transcribeAudioChunk transcribes a chunk and returns its segments with timestamps relative
+// to the start of the audio.
+func (ic *InferenceClient) transcribeAudioChunk(ctx context.Context, req *LongAudioTranscriptionRequest, parameters AutomaticSpeechRecognitionParameters, chunk audioChunk) ([]TranscriptionSegment, error) {
+	wav := &bytes.Buffer{}
+	if err := req.Audio.Slice(chunk.start, chunk.end).EncodeWAV(wav); err != nil {
+		return nil, err
+	}
+
+	res, err := ic.AutomaticSpeechRecognition(ctx, &AutomaticSpeechRecognitionRequest{
+		Inputs:      wav,
+		ContentType: "audio/wav",
+		Parameters:  parameters,
+		Options:     req.Options,
+		Model:       req.Model,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chunkStart, chunkEnd := req.Audio.offset(chunk.start), req.Audio.offset(chunk.end)
+
+	if len(res.Chunks) == 0 {
+		text := strings.TrimSpace(res.Text)
+		if text == "" {
+			return nil, nil
+		}
+
+		return []TranscriptionSegment{{Start: chunkStart, End: chunkEnd, Text: text}}, nil
+	}
+
+	segments := make([]TranscriptionSegment, 0, len(res.Chunks))
+
+	for _, c := range res.Chunks {
+		text := strings.TrimSpace(c.Text)
+		if text == "" {
+			continue
+		}
+
+		start := chunkStart + secondsToDuration(c.Timestamp[0])
+		end := chunkStart + secondsToDuration(c.Timestamp[1])
+
+		// The model may omit the end of the last segment
+		if end <= start || end > chunkEnd {
+			end = chunkEnd
+		}
+
+		segments = append(segments, TranscriptionSegment{Start: start, End: end, Text: text})
+	}
+
+	return segments, nil
+}
+
+// audioChunk represents a chunk of audio by its start and end sample index.
+type audioChunk struct {
+	start, end int
+}
+
+// planAudioChunks splits the audio into chunks of the specified length. Consecutive chunks overlap,
+// unless a chunk is cut at a silent frame near its end.
+func planAudioChunks(audio *Audio, chunkLength, overlap time.Duration, splitOnSilence bool, silenceThreshold float64) ([]audioChunk, error) {
+	chunkSamples := int(chunkLength.Seconds() * float64(audio.SampleRate))
+	overlapSamples := int(overlap.Seconds() * float64(audio.SampleRate))
+	frameSamples := audio.SampleRate / 50 // 20ms frames
+
+	if chunkSamples <= 0 {
+		return nil, errors.New("chunk length must be at least one sample")
+	}
+
+	if overlapSamples < 0 {
+		return nil, errors.New("overlap must not be negative")
+	}
+
+	chunks := []audioChunk{}
+
+	for start := 0; ; {
+		end := start + chunkSamples
+		if end >= len(audio.Samples) {
+			return append(chunks, audioChunk{start: start, end: len(audio.Samples)}), nil
+		}
+
+		next := end - overlapSamples
+
+		if splitOnSilence && frameSamples > 0 {
+			// Searches the last quarter of the chunk for the quietest frame
+			if cut, ok := findSilence(audio.Samples, end-chunkSamples/4, end, frameSamples, silenceThreshold); ok {
+				end, next = cut, cut
+			}
+		}
+
+		if next <= start {
+			return nil, errors.New("overlap must be shorter than the chunk length")
+		}
+
+		chunks = append(chunks, audioChunk{start: start, end: end})
+		start = next
+	}
+}
+
+// findSilence returns the center of the quietest frame between from and to if its energy is below the threshold.
+func findSilence(samples []float32, from, to, frameSamples int, threshold float64) (int, bool) {
+	best, bestEnergy := 0, threshold
+
+	step := frameSamples / 2
+	if step == 0 {
+		step = 1
+	}
+
+	for i := from; i+frameSamples <= to; i += step {
+		if energy := rms(samples[i : i+frameSamples]); energy < bestEnergy {
+			best, bestEnergy = i+frameSamples/2, energy
+		}
+	}
+
+	return best, best > 0
+}
+
+// mergeTranscriptions merges the segments of overlapping chunks. Within an overlap the segments of the
+// earlier chunk are used up to the middle of the overlap and the segments of the later chunk afterwards.
+// Words repeated at the boundary are removed.
+func mergeTranscriptions(audio *Audio, chunks []audioChunk, transcriptions [][]TranscriptionSegment) []TranscriptionSegment {
+	merged := []TranscriptionSegment{}
+
+	for i, segments := range transcriptions {
+		lower, upper := time.Duration(-1), time.Duration(math.MaxInt64)
+
+		if i > 0 && chunks[i].start < chunks[i-1].end {
+			lower = audio.offset((chunks[i].start + chunks[i-1].end) / 2)
+		}
+
+		if i < len(chunks)-1 && chunks[i+1].start < chunks[i].end {
+			upper = audio.offset((chunks[i+1].start + chunks[i].end) / 2)
+		}
+
+		first := true
+
+		for _, segment := range segments {
+			// Segments belong to the chunk that contains most of them
+			if mid := (segment.Start + segment.End) / 2; mid < lower || mid >= upper {
+				continue
+			}
+
+			if first && len(merged) > 0 && lower >= 0 {
+				segment.Text = removeRepeatedWords(merged[len(merged)-1].Text, segment.Text)
+				if segment.Text == "" {
+					continue
+				}
+			}
+
+			first = false
+
+			merged = append(merged, segment)
+		}
+	}
+
+	return merged
+}
+
+// removeRepeatedWords removes the longest prefix of next that repeats the end of prev.
+// Single repeated words are only removed if they make up the whole of next, since a
+// single common word is likely to repeat by chance.
+func removeRepeatedWords(prev, next string) string {
+	prevWords, nextWords := strings.Fields(prev), strings.Fields(next)
+
+	maxOverlap := len(prevWords)
+	if len(nextWords) < maxOverlap {
+		maxOverlap = len(nextWords)
+	}
+
+	for n := maxOverlap; n > 0; n-- {
+		if n == 1 && len(nextWords) > 1 {
+			break
+		}
+
+		if equalWords(prevWords[len(prevWords)-n:], nextWords[:n]) {
+			return strings.Join(nextWords[n:], " ")
+		}
+	}
+
+	return next
+}
+
+// equalWords compares words ignoring case and punctuation.
+func equalWords(a, b []string) bool {
+	for i := range a {
+		if normalizeWord(a[i]) != normalizeWord(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeWord lowercases the word and strips punctuation.
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, unicode.IsPunct))
+}
+
+// secondsToDuration converts seconds to a duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// formatSubtitleTimestamp formats the duration as hh:mm:ss followed by the separator and milliseconds.
+func formatSubtitleTimestamp(d time.Duration, sep string) string {
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
@@ -0,0 +1,288 @@
+package huggingface
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MetricType represents the type of a Prometheus metric family.
+type MetricType string
+
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeGauge     MetricType = "gauge"
+	MetricTypeHistogram MetricType = "histogram"
+	MetricTypeSummary   MetricType = "summary"
+	MetricTypeUntyped   MetricType = "untyped"
+)
+
+// MetricSample represents a single sample of a metric family.
+type MetricSample struct {
+	// The name of the sample, which includes suffixes like _bucket, _sum or _count for histograms and summaries.
+	Name string
+
+	// The labels of the sample.
+	Labels map[string]string
+
+	// The value of the sample.
+	Value float64
+
+	// The timestamp of the sample in milliseconds since epoch, or 0 if not present.
+	Timestamp int64
+}
+
+// MetricFamily represents a group of samples sharing a name, help text and type.
+type MetricFamily struct {
+	// The name of the metric family.
+	Name string
+
+	// The help text of the metric family.
+	Help string
+
+	// The type of the metric family.
+	Type MetricType
+
+	// The samples of the metric family.
+	Samples []MetricSample
+}
+
+// Metrics maps metric family names to metric families.
+type Metrics map[string]*MetricFamily
+
+// Value returns the value of the first sample with the specified name whose labels
+// contain all the specified labels. The boolean reports whether such a sample exists.
+func (m Metrics) Value(name string, labels map[string]string) (float64, bool) {
+	for _, family := range m {
+		for _, sample := range family.Samples {
+			if sample.Name == name && hasLabels(sample.Labels, labels) {
+				return sample.Value, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// Sum returns the sum of the values of all samples with the specified name,
+// e.g. to aggregate a metric across label values.
+func (m Metrics) Sum(name string) float64 {
+	sum := 0.0
+
+	for _, family := range m {
+		for _, sample := range family.Samples {
+			if sample.Name == name {
+				sum += sample.Value
+			}
+		}
+	}
+
+	return sum
+}
+
+// ParseMetrics parses metrics in the Prometheus text exposition format.
+func ParseMetrics(r io.Reader) (Metrics, error) {
+	metrics := Metrics{}
+
+	familyFor := func(name string) *MetricFamily {
+		if family, ok := metrics[name]; ok {
+			return family
+		}
+
+		family := &MetricFamily{Name: name, Type: MetricTypeUntyped}
+		metrics[name] = family
+
+		return family
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "#")), " ", 3)
+			if len(fields) < 3 {
+				continue
+			}
+
+			switch fields[0] {
+			case "HELP":
+				familyFor(fields[1]).Help = unescapeMetricString(fields[2])
+			case "TYPE":
+				familyFor(fields[1]).Type = MetricType(strings.TrimSpace(fields[2]))
+			}
+
+			continue
+		}
+
+		sample, err := parseMetricSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric on line %d: %w", lineNumber, err)
+		}
+
+		family := familyFor(metricFamilyName(metrics, sample.Name))
+		family.Samples = append(family.Samples, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+// metricFamilyName returns the name of the family a sample belongs to, mapping
+// the suffixed samples of histograms and summaries to their family.
+func metricFamilyName(metrics Metrics, sampleName string) string {
+	if _, ok := metrics[sampleName]; ok {
+		return sampleName
+	}
+
+	for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+		name := strings.TrimSuffix(sampleName, suffix)
+		if name == sampleName {
+			continue
+		}
+
+		if family, ok := metrics[name]; ok && (family.Type == MetricTypeHistogram || family.Type == MetricTypeSummary) {
+			return name
+		}
+	}
+
+	return sampleName
+}
+
+// parseMetricSample parses a sample line of the form name{labels} value [timestamp].
+func parseMetricSample(line string) (MetricSample, error) {
+	sample := MetricSample{Labels: map[string]string{}}
+
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd <= 0 {
+		return sample, fmt.Errorf("missing value in %q", line)
+	}
+
+	sample.Name = line[:nameEnd]
+	rest := line[nameEnd:]
+
+	if strings.HasPrefix(rest, "{") {
+		labels, n, err := parseMetricLabels(rest)
+		if err != nil {
+			return sample, err
+		}
+
+		sample.Labels = labels
+		rest = rest[n:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("invalid value in %q", line)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, err
+	}
+
+	sample.Value = value
+
+	if len(fields) == 2 {
+		timestamp, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return sample, err
+		}
+
+		sample.Timestamp = timestamp
+	}
+
+	return sample, nil
+}
+
+// parseMetricLabels parses a label set starting with '{' and returns the labels
+// and the number of bytes consumed.
+func parseMetricLabels(s string) (map[string]string, int, error) {
+	labels := map[string]string{}
+	i := 1
+
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == ',') {
+			i++
+		}
+
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated labels in %q", s)
+		}
+
+		if s[i] == '}' {
+			return labels, i + 1, nil
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return nil, 0, fmt.Errorf("invalid label in %q", s)
+		}
+
+		name := strings.TrimSpace(s[i : i+eq])
+		i += eq + 1
+
+		if i >= len(s) || s[i] != '"' {
+			return nil, 0, fmt.Errorf("unquoted label value in %q", s)
+		}
+
+		i++
+
+		var value strings.Builder
+
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+
+				continue
+			}
+
+			value.WriteByte(s[i])
+		}
+
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated label value in %q", s)
+		}
+
+		i++
+
+		labels[name] = value.String()
+	}
+}
+
+// unescapeMetricString unescapes backslashes and line feeds in help texts.
+func unescapeMetricString(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(s)
+}
+
+// hasLabels reports whether labels contains all entries of subset.
+func hasLabels(labels, subset map[string]string) bool {
+	for k, v := range subset {
+		if labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,20 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package huggingface
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the file into memory on platforms without memory mapping.
+func mapFile(f *os.File, size int64) ([]byte, func() error, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+
+	return data, func() error {
+		return nil
+	}, nil
+}
@@ -0,0 +1,20 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package huggingface
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file read-only into memory. The returned function unmaps the file.
+func mapFile(f *os.File, size int64) ([]byte, func() error, error) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, func() error {
+		return syscall.Munmap(data)
+	}, nil
+}
@@ -0,0 +1,161 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"sort"
+)
+
+// BoundingBox represents the pixel coordinates of an object within an image.
+type BoundingBox struct {
+	// The x-coordinate of the top-left corner.
+	XMin int `json:"xmin"`
+
+	// The y-coordinate of the top-left corner.
+	YMin int `json:"ymin"`
+
+	// The x-coordinate of the bottom-right corner.
+	XMax int `json:"xmax"`
+
+	// The y-coordinate of the bottom-right corner.
+	YMax int `json:"ymax"`
+}
+
+// Rect returns the bounding box as image.Rectangle.
+func (b BoundingBox) Rect() image.Rectangle {
+	return image.Rect(b.XMin, b.YMin, b.XMax, b.YMax)
+}
+
+// Area returns the area of the bounding box in pixels.
+func (b BoundingBox) Area() int {
+	size := b.Rect().Size()
+
+	return size.X * size.Y
+}
+
+// IoU returns the intersection over union of the bounding boxes.
+func (b BoundingBox) IoU(other BoundingBox) float64 {
+	intersection := b.Rect().Intersect(other.Rect())
+	if intersection.Empty() {
+		return 0
+	}
+
+	i := intersection.Dx() * intersection.Dy()
+
+	return float64(i) / float64(b.Area()+other.Area()-i)
+}
+
+// Scale scales the bounding box from an image of size from to an image of size to,
+// e.g. to map boxes detected on a resized image back to the original image.
+// The bounding box is returned unchanged if from is not a valid size.
+func (b BoundingBox) Scale(from, to image.Point) BoundingBox {
+	if from.X <= 0 || from.Y <= 0 {
+		return b
+	}
+
+	sx := float64(to.X) / float64(from.X)
+	sy := float64(to.Y) / float64(from.Y)
+
+	return BoundingBox{
+		XMin: int(float64(b.XMin) * sx),
+		YMin: int(float64(b.YMin) * sy),
+		XMax: int(float64(b.XMax) * sx),
+		YMax: int(float64(b.YMax) * sy),
+	}
+}
+
+// DetectedObject represents an object detected within an image.
+type DetectedObject struct {
+	// The label of the object (model specific).
+	Label string `json:"label"`
+
+	// A float that represents how likely it is that the detected object belongs to the label.
+	Score float64 `json:"score"`
+
+	// The bounding box of the object.
+	Box BoundingBox `json:"box"`
+}
+
+// Used with ObjectDetectionRequest
+type ObjectDetectionParameters struct {
+	// (Default: 0.9). Float. The probability necessary to make a prediction.
+	Threshold *float64 `json:"threshold,omitempty"`
+}
+
+// Request structure for the object detection endpoint
+type ObjectDetectionRequest struct {
+	// (Required) The image to detect objects in.
+	Inputs     ImageInput
+	Parameters ObjectDetectionParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the object detection endpoint
+type ObjectDetectionResponse []DetectedObject
+
+// Scale scales the bounding boxes of all objects from an image of size from to an image of size to.
+func (r ObjectDetectionResponse) Scale(from, to image.Point) ObjectDetectionResponse {
+	scaled := make(ObjectDetectionResponse, len(r))
+
+	for i, object := range r {
+		object.Box = object.Box.Scale(from, to)
+		scaled[i] = object
+	}
+
+	return scaled
+}
+
+// ObjectDetection performs object detection using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided image.
+// The response contains the detected objects or an error if the request fails.
+func (ic *InferenceClient) ObjectDetection(ctx context.Context, req *ObjectDetectionRequest) (ObjectDetectionResponse, error) {
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "object-detection", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	objectDetectionResponse := ObjectDetectionResponse{}
+	if err := json.Unmarshal(body, &objectDetectionResponse); err != nil {
+		return nil, err
+	}
+
+	return objectDetectionResponse, nil
+}
+
+// NonMaxSuppression removes objects whose bounding box overlaps with the box of a higher scored object
+// of the same label by more than the IoU threshold. If classAgnostic is true, objects are suppressed
+// regardless of their label. The returned objects are sorted by descending score.
+func NonMaxSuppression(objects []DetectedObject, iouThreshold float64, classAgnostic bool) []DetectedObject {
+	sorted := make([]DetectedObject, len(objects))
+	copy(sorted, objects)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+
+	kept := []DetectedObject{}
+
+	for _, candidate := range sorted {
+		suppressed := false
+
+		for _, object := range kept {
+			if (classAgnostic || object.Label == candidate.Label) && object.Box.IoU(candidate.Box) > iouThreshold {
+				suppressed = true
+				break
+			}
+		}
+
+		if !suppressed {
+			kept = append(kept, candidate)
+		}
+	}
+
+	return kept
+}
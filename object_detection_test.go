@@ -0,0 +1,126 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundingBox(t *testing.T) {
+	box := BoundingBox{XMin: 10, YMin: 10, XMax: 30, YMax: 20}
+
+	assert.Equal(t, 200, box.Area())
+	assert.Equal(t, BoundingBox{XMin: 20, YMin: 5, XMax: 60, YMax: 10}, box.Scale(image.Pt(100, 100), image.Pt(200, 50)))
+	assert.Equal(t, box, box.Scale(image.Pt(0, 100), image.Pt(200, 50)))
+	assert.InDelta(t, 1.0/3.0, box.IoU(BoundingBox{XMin: 20, YMin: 10, XMax: 40, YMax: 20}), 1e-9)
+	assert.Equal(t, 0.0, box.IoU(BoundingBox{XMin: 50, YMin: 50, XMax: 60, YMax: 60}))
+}
+
+func TestObjectDetection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/facebook/detr-resnet-50", r.URL.Path)
+
+		payload := struct {
+			Inputs     []byte                    `json:"inputs"`
+			Parameters ObjectDetectionParameters `json:"parameters"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []byte("image"), payload.Inputs)
+		assert.Equal(t, 0.5, *payload.Parameters.Threshold)
+
+		_, _ = w.Write([]byte(`[{"label":"cat","score":0.98,"box":{"xmin":10,"ymin":20,"xmax":30,"ymax":40}}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	res, err := client.ObjectDetection(context.Background(), &ObjectDetectionRequest{
+		Inputs:     ImageFromBytes([]byte("image")),
+		Parameters: ObjectDetectionParameters{Threshold: PTR(0.5)},
+		Model:      "facebook/detr-resnet-50",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ObjectDetectionResponse{
+		{Label: "cat", Score: 0.98, Box: BoundingBox{XMin: 10, YMin: 20, XMax: 30, YMax: 40}},
+	}, res)
+	assert.Equal(t, BoundingBox{XMin: 20, YMin: 40, XMax: 60, YMax: 80}, res.Scale(image.Pt(100, 100), image.Pt(200, 200))[0].Box)
+
+	_, err = client.ObjectDetection(context.Background(), &ObjectDetectionRequest{})
+	assert.EqualError(t, err, "inputs are required")
+}
+
+func TestNonMaxSuppression(t *testing.T) {
+	objects := []DetectedObject{
+		{Label: "cat", Score: 0.8, Box: BoundingBox{XMin: 0, YMin: 0, XMax: 10, YMax: 10}},
+		{Label: "cat", Score: 0.9, Box: BoundingBox{XMin: 1, YMin: 1, XMax: 11, YMax: 11}},
+		{Label: "dog", Score: 0.7, Box: BoundingBox{XMin: 0, YMin: 0, XMax: 10, YMax: 10}},
+	}
+
+	kept := NonMaxSuppression(objects, 0.5, false)
+	assert.Len(t, kept, 2)
+	assert.Equal(t, 0.9, kept[0].Score)
+	assert.Equal(t, "dog", kept[1].Label)
+
+	assert.Len(t, NonMaxSuppression(objects, 0.5, true), 1)
+}
+
+func TestDrawDetectedObjects(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+
+	DrawDetectedObjects(img, []DetectedObject{
+		{Label: "cat", Score: 0.9, Box: BoundingBox{XMin: 10, YMin: 30, XMax: 50, YMax: 60}},
+	})
+
+	c := labelColor("cat")
+	assert.Equal(t, c, img.RGBAAt(10, 45))
+	assert.Equal(t, c, img.RGBAAt(49, 59))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(30, 45))
+}
+
+func TestZeroShotObjectDetection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := struct {
+			Inputs     []byte                            `json:"inputs"`
+			Parameters ZeroShotObjectDetectionParameters `json:"parameters"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []byte("image"), payload.Inputs)
+		assert.Equal(t, []string{"cat", "remote control"}, payload.Parameters.CandidateLabels)
+
+		_, _ = w.Write([]byte(`[{"label":"cat","score":0.9,"box":{"xmin":1,"ymin":2,"xmax":3,"ymax":4}}]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	t.Run("Detect objects", func(t *testing.T) {
+		res, err := client.ZeroShotObjectDetection(context.Background(), &ZeroShotObjectDetectionRequest{
+			Inputs: ImageFromBytes([]byte("image")),
+			Parameters: ZeroShotObjectDetectionParameters{
+				CandidateLabels: []string{"cat", "remote control"},
+			},
+			Model: "google/owlvit-base-patch32",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ZeroShotObjectDetectionResponse{
+			{Label: "cat", Score: 0.9, Box: BoundingBox{XMin: 1, YMin: 2, XMax: 3, YMax: 4}},
+		}, res)
+	})
+
+	t.Run("Missing candidate labels", func(t *testing.T) {
+		_, err := client.ZeroShotObjectDetection(context.Background(), &ZeroShotObjectDetectionRequest{
+			Inputs: ImageFromBytes([]byte("image")),
+		})
+		assert.EqualError(t, err, "candidateLabels are required")
+	})
+}
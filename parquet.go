@@ -0,0 +1,785 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Physical types of parquet columns.
+const (
+	parquetBoolean           = 0
+	parquetInt32             = 1
+	parquetInt64             = 2
+	parquetInt96             = 3
+	parquetFloat             = 4
+	parquetDouble            = 5
+	parquetByteArray         = 6
+	parquetFixedLenByteArray = 7
+)
+
+var parquetTypeNames = []string{"BOOLEAN", "INT32", "INT64", "INT96", "FLOAT", "DOUBLE", "BYTE_ARRAY", "FIXED_LEN_BYTE_ARRAY"}
+
+// Repetition types of parquet schema elements.
+const (
+	parquetRequired = 0
+	parquetOptional = 1
+	parquetRepeated = 2
+)
+
+// Ids of the members of the logical type union of parquet schema elements.
+const (
+	parquetLogicalString    = 1
+	parquetLogicalMap       = 2
+	parquetLogicalList      = 3
+	parquetLogicalEnum      = 4
+	parquetLogicalDecimal   = 5
+	parquetLogicalDate      = 6
+	parquetLogicalTime      = 7
+	parquetLogicalTimestamp = 8
+	parquetLogicalJSON      = 12
+	parquetLogicalUUID      = 14
+)
+
+var parquetLogicalNames = map[int16]string{
+	parquetLogicalString: "STRING", parquetLogicalMap: "MAP", parquetLogicalList: "LIST", parquetLogicalEnum: "ENUM",
+	parquetLogicalDecimal: "DECIMAL", parquetLogicalDate: "DATE", parquetLogicalTime: "TIME", parquetLogicalTimestamp: "TIMESTAMP",
+	10: "INTEGER", parquetLogicalJSON: "JSON", 13: "BSON", parquetLogicalUUID: "UUID", 15: "FLOAT16",
+}
+
+// Units of the time and timestamp logical types.
+const (
+	parquetMillis = 1
+	parquetMicros = 2
+	parquetNanos  = 3
+)
+
+// Legacy converted types of parquet schema elements, which are used by older writers.
+const (
+	parquetConvertedUTF8            = 0
+	parquetConvertedMap             = 1
+	parquetConvertedMapKeyValue     = 2
+	parquetConvertedList            = 3
+	parquetConvertedEnum            = 4
+	parquetConvertedDecimal         = 5
+	parquetConvertedDate            = 6
+	parquetConvertedTimeMillis      = 7
+	parquetConvertedTimeMicros      = 8
+	parquetConvertedTimestampMillis = 9
+	parquetConvertedTimestampMicros = 10
+	parquetConvertedUint8           = 11
+	parquetConvertedUint16          = 12
+	parquetConvertedUint32          = 13
+	parquetConvertedUint64          = 14
+	parquetConvertedJSON            = 19
+)
+
+// parquetMagic is stored at the start and the end of parquet files.
+const parquetMagic = "PAR1"
+
+// parquetNode is a node of the schema tree of a parquet file.
+type parquetNode struct {
+	element  parquetSchemaElement
+	children []*parquetNode
+
+	// The index of the leaf column, -1 for groups.
+	leaf int
+
+	// The definition and repetition level of the node, which are the maximum levels for leaves.
+	maxDefinitionLevel int16
+	maxRepetitionLevel int16
+
+	// The nodes from the top-level column to this node.
+	path []*parquetNode
+}
+
+func (n *parquetNode) name() string {
+	return n.element.name
+}
+
+func (n *parquetNode) repeated() bool {
+	return n.element.repetitionType == parquetRepeated
+}
+
+// ParquetColumn describes a leaf column of a parquet file.
+type ParquetColumn struct {
+	// The path of the column, which has more than one element for columns nested in groups.
+	Path []string
+
+	// The physical type, e.g. INT64 or BYTE_ARRAY.
+	Type string
+
+	// The logical type, e.g. STRING or TIMESTAMP, empty if the column has none.
+	LogicalType string
+
+	// Whether the column may contain null values.
+	Optional bool
+}
+
+// ParquetReader reads the rows of a parquet file. The reader fetches the footer when created and the
+// column chunks of a row group when the row group is read, so only the requested columns are transferred
+// when reading from remote storage.
+type ParquetReader struct {
+	r        io.ReaderAt
+	size     int64
+	metadata *parquetFileMetaData
+	root     *parquetNode
+	leaves   []*parquetNode
+}
+
+// NewParquetReader creates a new ParquetReader reading the parquet file of the specified size from r.
+func NewParquetReader(r io.ReaderAt, size int64) (*ParquetReader, error) {
+	if size < 12 {
+		return nil, errors.New("parquet: file is too small")
+	}
+
+	footer := make([]byte, 8)
+	if _, err := r.ReadAt(footer, size-8); err != nil {
+		return nil, err
+	}
+
+	if string(footer[4:]) != parquetMagic {
+		return nil, errors.New("parquet: invalid magic number")
+	}
+
+	length := int64(binary.LittleEndian.Uint32(footer))
+	if length > size-12 {
+		return nil, errors.New("parquet: invalid metadata length")
+	}
+
+	data := make([]byte, length)
+	if _, err := r.ReadAt(data, size-8-length); err != nil {
+		return nil, err
+	}
+
+	metadata, err := (&thriftReader{data: data}).readFileMetaData()
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &ParquetReader{r: r, size: size, metadata: metadata}
+	if err := pr.buildSchema(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// buildSchema builds the schema tree from the flattened schema elements, which are stored depth first.
+func (pr *ParquetReader) buildSchema() error {
+	schema := pr.metadata.schema
+	if len(schema) == 0 {
+		return errors.New("parquet: schema is empty")
+	}
+
+	pos := 0
+
+	var build func(parent *parquetNode) (*parquetNode, error)
+
+	build = func(parent *parquetNode) (*parquetNode, error) {
+		if pos >= len(schema) {
+			return nil, errors.New("parquet: invalid schema")
+		}
+
+		node := &parquetNode{element: schema[pos], leaf: -1}
+		pos++
+
+		if parent != nil {
+			node.maxDefinitionLevel = parent.maxDefinitionLevel
+			node.maxRepetitionLevel = parent.maxRepetitionLevel
+			node.path = append(append([]*parquetNode{}, parent.path...), node)
+
+			if node.element.repetitionType != parquetRequired {
+				node.maxDefinitionLevel++
+			}
+
+			if node.repeated() {
+				node.maxRepetitionLevel++
+			}
+		}
+
+		if node.element.numChildren == 0 && parent != nil {
+			node.leaf = len(pr.leaves)
+			pr.leaves = append(pr.leaves, node)
+
+			return node, nil
+		}
+
+		for i := int32(0); i < node.element.numChildren; i++ {
+			child, err := build(node)
+			if err != nil {
+				return nil, err
+			}
+
+			node.children = append(node.children, child)
+		}
+
+		return node, nil
+	}
+
+	root, err := build(nil)
+	if err != nil {
+		return err
+	}
+
+	pr.root = root
+
+	for _, rowGroup := range pr.metadata.rowGroups {
+		if len(rowGroup.columns) != len(pr.leaves) {
+			return fmt.Errorf("parquet: row group has %d columns, expected %d", len(rowGroup.columns), len(pr.leaves))
+		}
+	}
+
+	return nil
+}
+
+// NumRows returns the number of rows of the file.
+func (pr *ParquetReader) NumRows() int64 {
+	return pr.metadata.numRows
+}
+
+// NumRowGroups returns the number of row groups of the file.
+func (pr *ParquetReader) NumRowGroups() int {
+	return len(pr.metadata.rowGroups)
+}
+
+// Columns returns the leaf columns of the file.
+func (pr *ParquetReader) Columns() []ParquetColumn {
+	columns := make([]ParquetColumn, len(pr.leaves))
+
+	for i, leaf := range pr.leaves {
+		path := make([]string, len(leaf.path))
+		for j, node := range leaf.path {
+			path[j] = node.name()
+		}
+
+		typ := fmt.Sprint(leaf.element.typ)
+		if leaf.element.typ >= 0 && int(leaf.element.typ) < len(parquetTypeNames) {
+			typ = parquetTypeNames[leaf.element.typ]
+		}
+
+		columns[i] = ParquetColumn{
+			Path:        path,
+			Type:        typ,
+			LogicalType: parquetLogicalNames[leaf.element.logicalType],
+			Optional:    leaf.maxDefinitionLevel > 0,
+		}
+	}
+
+	return columns
+}
+
+// ReadRowGroup reads the rows of the row group with the specified index. Only the top-level columns
+// with the specified names are read, or all columns if no names are specified. Lists are returned as
+// []any, groups and maps as map[string]any, strings as string and other binary values as []byte.
+func (pr *ParquetReader) ReadRowGroup(index int, columns ...string) ([]map[string]any, error) {
+	if index < 0 || index >= len(pr.metadata.rowGroups) {
+		return nil, fmt.Errorf("parquet: row group %d out of range", index)
+	}
+
+	leaves, err := pr.selectLeaves(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rowGroup := pr.metadata.rowGroups[index]
+
+	if rowGroup.numRows < 0 || (len(leaves) == 0 && rowGroup.numRows > 0) {
+		return nil, fmt.Errorf("parquet: invalid number of rows %d", rowGroup.numRows)
+	}
+
+	chunks := make([]*parquetColumnData, len(leaves))
+
+	for i, leaf := range leaves {
+		data, err := pr.readColumnChunk(&rowGroup.columns[leaf.leaf], leaf)
+		if err != nil {
+			return nil, err
+		}
+
+		// The rows are allocated after decoding the column chunks, which contain at least one level per row
+		if numRows := data.numRows(); numRows < rowGroup.numRows {
+			return nil, fmt.Errorf("parquet: column %s has %d rows, expected %d", leaf.name(), numRows, rowGroup.numRows)
+		}
+
+		chunks[i] = data
+	}
+
+	rows := make([]map[string]any, rowGroup.numRows)
+	for i := range rows {
+		rows[i] = map[string]any{}
+	}
+
+	for i, leaf := range leaves {
+		if err := assembleColumn(rows, leaf, chunks[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, row := range rows {
+		for _, node := range pr.root.children {
+			if value, ok := row[node.name()]; ok {
+				row[node.name()] = finalizeParquetValue(node, value)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// Rows iterates over the rows of all row groups of the file, see ReadRowGroup.
+// The row groups are read while iterating.
+func (pr *ParquetReader) Rows(ctx context.Context, columns ...string) *Iterator[DatasetRow] {
+	files := func(context.Context) (int, error) {
+		return 1, nil
+	}
+
+	return newParquetRowIterator(ctx, files, func(context.Context, int) (*ParquetReader, error) {
+		return pr, nil
+	}, columns, 0, 0)
+}
+
+// selectLeaves returns the leaves of the top-level columns with the specified names.
+func (pr *ParquetReader) selectLeaves(columns []string) ([]*parquetNode, error) {
+	if len(columns) == 0 {
+		return pr.leaves, nil
+	}
+
+	selected := make(map[string]bool, len(columns))
+
+	for _, name := range columns {
+		found := false
+
+		for _, node := range pr.root.children {
+			if node.name() == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("parquet: unknown column %q", name)
+		}
+
+		selected[name] = true
+	}
+
+	leaves := []*parquetNode{}
+
+	for _, leaf := range pr.leaves {
+		if selected[leaf.path[0].name()] {
+			leaves = append(leaves, leaf)
+		}
+	}
+
+	return leaves, nil
+}
+
+// readColumnChunk reads and decodes a column chunk.
+func (pr *ParquetReader) readColumnChunk(column *parquetColumnChunk, leaf *parquetNode) (*parquetColumnData, error) {
+	offset := column.dataPageOffset
+	if column.dictionaryPageOffset > 0 && column.dictionaryPageOffset < offset {
+		offset = column.dictionaryPageOffset
+	}
+
+	if column.totalCompressedSize <= 0 || column.totalCompressedSize > math.MaxInt32 ||
+		offset < 0 || offset > pr.size || column.totalCompressedSize > pr.size-offset {
+		return nil, fmt.Errorf("parquet: invalid size of column chunk %v", column.path)
+	}
+
+	data := make([]byte, column.totalCompressedSize)
+
+	n, err := pr.r.ReadAt(data, offset)
+	if n < len(data) {
+		if err == nil || errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return decodeColumnChunk(data, column, leaf)
+}
+
+// assembleColumn adds the values of a leaf column to the rows using the repetition and definition levels.
+func assembleColumn(rows []map[string]any, leaf *parquetNode, data *parquetColumnData) error {
+	// Leaves without repeated ancestors are added directly, while the values of repeated leaves are
+	// added to a separate row first and merged, because they share lists with their sibling leaves.
+	nested := leaf.maxRepetitionLevel > 0
+
+	row, valueIndex := -1, 0
+
+	var current map[string]any
+
+	n := len(data.values)
+	if data.definitionLevels != nil {
+		n = len(data.definitionLevels)
+	}
+
+	for i := 0; i < n; i++ {
+		var repetitionLevel, definitionLevel int16
+		if data.repetitionLevels != nil {
+			repetitionLevel = data.repetitionLevels[i]
+		}
+
+		definitionLevel = leaf.maxDefinitionLevel
+		if data.definitionLevels != nil {
+			definitionLevel = data.definitionLevels[i]
+		}
+
+		if repetitionLevel == 0 {
+			if nested && current != nil {
+				mergeParquetValue(rows[row], current)
+			}
+
+			row++
+			if row >= len(rows) {
+				return fmt.Errorf("parquet: column %s has more rows than the row group", leaf.name())
+			}
+
+			current = rows[row]
+			if nested {
+				current = map[string]any{}
+			}
+		}
+
+		var value any
+
+		if definitionLevel == leaf.maxDefinitionLevel {
+			if valueIndex >= len(data.values) {
+				return fmt.Errorf("parquet: column %s has fewer values than levels", leaf.name())
+			}
+
+			value = convertParquetValue(leaf.element, data.values[valueIndex])
+			valueIndex++
+		}
+
+		addParquetValue(current, leaf, repetitionLevel, definitionLevel, value)
+	}
+
+	if nested && current != nil {
+		mergeParquetValue(rows[row], current)
+	}
+
+	return nil
+}
+
+// addParquetValue adds a value of a leaf to the row, creating the groups and list elements of its path.
+func addParquetValue(row map[string]any, leaf *parquetNode, repetitionLevel, definitionLevel int16, value any) {
+	current := row
+
+	for i, node := range leaf.path {
+		last := i == len(leaf.path)-1
+
+		if definitionLevel < node.maxDefinitionLevel {
+			if _, ok := current[node.name()]; !ok {
+				if node.repeated() {
+					current[node.name()] = []any{}
+				} else {
+					current[node.name()] = nil
+				}
+			}
+
+			return
+		}
+
+		if node.repeated() {
+			list, _ := current[node.name()].([]any)
+
+			// A repetition level above the level of the node continues the last element
+			if repetitionLevel > node.maxRepetitionLevel && len(list) > 0 {
+				if last {
+					return
+				}
+
+				current, _ = list[len(list)-1].(map[string]any)
+
+				continue
+			}
+
+			if last {
+				current[node.name()] = append(list, value)
+				return
+			}
+
+			child := map[string]any{}
+			current[node.name()] = append(list, child)
+			current = child
+
+			continue
+		}
+
+		if last {
+			current[node.name()] = value
+			return
+		}
+
+		child, ok := current[node.name()].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			current[node.name()] = child
+		}
+
+		current = child
+	}
+}
+
+// mergeParquetValue merges the groups and lists of src into dst.
+func mergeParquetValue(dst, src map[string]any) {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if !ok || existing == nil {
+			dst[key] = value
+			continue
+		}
+
+		switch existing := existing.(type) {
+		case map[string]any:
+			if m, ok := value.(map[string]any); ok {
+				mergeParquetValue(existing, m)
+			}
+		case []any:
+			list, _ := value.([]any)
+
+			for i, item := range list {
+				if i >= len(existing) {
+					existing = append(existing, item)
+					continue
+				}
+
+				if m, ok := existing[i].(map[string]any); ok {
+					if other, ok := item.(map[string]any); ok {
+						mergeParquetValue(m, other)
+					}
+				} else if existing[i] == nil {
+					existing[i] = item
+				}
+			}
+
+			dst[key] = existing
+		}
+	}
+}
+
+// finalizeParquetValue converts the groups annotated as lists or maps to []any and map[string]any.
+func finalizeParquetValue(node *parquetNode, value any) any {
+	if node.repeated() {
+		list, _ := value.([]any)
+		for i, item := range list {
+			list[i] = finalizeParquetElement(node, item)
+		}
+
+		return list
+	}
+
+	return finalizeParquetElement(node, value)
+}
+
+func finalizeParquetElement(node *parquetNode, value any) any {
+	group, ok := value.(map[string]any)
+	if !ok || node.leaf >= 0 {
+		return value
+	}
+
+	element := node.element
+
+	if len(node.children) == 1 && node.children[0].repeated() {
+		repeated := node.children[0]
+		items, _ := group[repeated.name()].([]any)
+
+		switch {
+		case element.logicalType == parquetLogicalList || element.convertedType == parquetConvertedList:
+			list := make([]any, len(items))
+
+			for i, item := range items {
+				// The repeated group is the element itself in legacy lists, otherwise it wraps the element
+				if repeated.leaf >= 0 || len(repeated.children) > 1 || repeated.name() == "array" || repeated.name() == node.name()+"_tuple" {
+					list[i] = finalizeParquetElement(repeated, item)
+				} else {
+					child := repeated.children[0]
+					itemGroup, _ := item.(map[string]any)
+					list[i] = finalizeParquetValue(child, itemGroup[child.name()])
+				}
+			}
+
+			return list
+		case (element.logicalType == parquetLogicalMap || element.convertedType == parquetConvertedMap ||
+			element.convertedType == parquetConvertedMapKeyValue) && len(repeated.children) == 2:
+			key, val := repeated.children[0], repeated.children[1]
+			m := make(map[string]any, len(items))
+
+			for _, item := range items {
+				itemGroup, _ := item.(map[string]any)
+				m[fmt.Sprint(finalizeParquetValue(key, itemGroup[key.name()]))] = finalizeParquetValue(val, itemGroup[val.name()])
+			}
+
+			return m
+		}
+	}
+
+	for _, child := range node.children {
+		if v, ok := group[child.name()]; ok {
+			group[child.name()] = finalizeParquetValue(child, v)
+		}
+	}
+
+	return group
+}
+
+// convertParquetValue converts a physical value to the Go type of the logical type of the column.
+func convertParquetValue(element parquetSchemaElement, value any) any {
+	logical, converted := element.logicalType, element.convertedType
+
+	switch v := value.(type) {
+	case []byte:
+		switch {
+		case logical == parquetLogicalString || logical == parquetLogicalEnum || logical == parquetLogicalJSON ||
+			converted == parquetConvertedUTF8 || converted == parquetConvertedEnum || converted == parquetConvertedJSON:
+			return string(v)
+		case logical == parquetLogicalDecimal || converted == parquetConvertedDecimal:
+			unscaled := new(big.Int).SetBytes(v)
+			if len(v) > 0 && v[0]&0x80 != 0 {
+				unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(v))*8))
+			}
+
+			f, _ := new(big.Float).SetInt(unscaled).Float64()
+
+			return f / math.Pow10(int(element.scale))
+		case logical == parquetLogicalUUID && len(v) == 16:
+			s := hex.EncodeToString(v)
+			return strings.Join([]string{s[:8], s[8:12], s[12:16], s[16:20], s[20:]}, "-")
+		}
+
+		return bytes.Clone(v)
+	case int32:
+		switch {
+		case logical == parquetLogicalDate || converted == parquetConvertedDate:
+			return time.Unix(int64(v)*86400, 0).UTC()
+		case logical == parquetLogicalDecimal || converted == parquetConvertedDecimal:
+			return float64(v) / math.Pow10(int(element.scale))
+		case logical == parquetLogicalTime || converted == parquetConvertedTimeMillis:
+			return time.Duration(v) * time.Millisecond
+		case converted == parquetConvertedUint8 || converted == parquetConvertedUint16 || converted == parquetConvertedUint32:
+			return uint32(v)
+		}
+	case int64:
+		switch {
+		case logical == parquetLogicalTimestamp || converted == parquetConvertedTimestampMillis || converted == parquetConvertedTimestampMicros:
+			switch {
+			case element.timeUnit == parquetMillis || (logical == 0 && converted == parquetConvertedTimestampMillis):
+				return time.UnixMilli(v).UTC()
+			case element.timeUnit == parquetNanos:
+				return time.Unix(0, v).UTC()
+			default:
+				return time.UnixMicro(v).UTC()
+			}
+		case logical == parquetLogicalTime || converted == parquetConvertedTimeMicros:
+			if element.timeUnit == parquetNanos {
+				return time.Duration(v)
+			}
+
+			return time.Duration(v) * time.Microsecond
+		case logical == parquetLogicalDecimal || converted == parquetConvertedDecimal:
+			return float64(v) / math.Pow10(int(element.scale))
+		case converted == parquetConvertedUint64:
+			return uint64(v)
+		}
+	case parquetInt96Value:
+		nanos := int64(binary.LittleEndian.Uint64(v[:8]))
+		days := int64(binary.LittleEndian.Uint32(v[8:])) - 2440588
+
+		return time.Unix(days*86400, nanos).UTC()
+	}
+
+	return value
+}
+
+// newParquetRowIterator creates an iterator over the rows of the parquet files opened by open, which
+// reads the files one row group at a time. The number of files is requested from files when the first
+// rows are fetched. The first offset rows are skipped without reading the row groups containing them.
+func newParquetRowIterator(ctx context.Context, files func(ctx context.Context) (int, error), open func(ctx context.Context, file int) (*ParquetReader, error), columns []string, offset int64, limit int) *Iterator[DatasetRow] {
+	var (
+		reader   *ParquetReader
+		opened   = -1
+		numFiles = -1
+		rowIdx   int64
+	)
+
+	// The cursor of the iterator is the index of the file and the row group
+	cursor := func(file, rowGroup int) string {
+		return fmt.Sprintf("%d/%d", file, rowGroup)
+	}
+
+	fetch := func(ctx context.Context, position string) ([]DatasetRow, string, error) {
+		var file, rowGroup int
+		if _, err := fmt.Sscanf(position, "%d/%d", &file, &rowGroup); err != nil {
+			return nil, "", err
+		}
+
+		if numFiles < 0 {
+			n, err := files(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+
+			numFiles = n
+		}
+
+		for file < numFiles {
+			if opened != file {
+				r, err := open(ctx, file)
+				if err != nil {
+					return nil, "", err
+				}
+
+				reader, opened = r, file
+			}
+
+			if rowGroup >= reader.NumRowGroups() {
+				file, rowGroup = file+1, 0
+				continue
+			}
+
+			numRows := reader.metadata.rowGroups[rowGroup].numRows
+			if offset >= numRows {
+				offset -= numRows
+				rowIdx += numRows
+				rowGroup++
+
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return nil, "", err
+			}
+
+			rows, err := reader.ReadRowGroup(rowGroup, columns...)
+			if err != nil {
+				return nil, "", err
+			}
+
+			rows = rows[offset:]
+			rowIdx += offset
+			offset = 0
+
+			items := make([]DatasetRow, len(rows))
+			for i, row := range rows {
+				items[i] = DatasetRow{RowIdx: rowIdx, Row: row}
+				rowIdx++
+			}
+
+			return items, cursor(file, rowGroup+1), nil
+		}
+
+		return nil, "", nil
+	}
+
+	return newIterator(ctx, cursor(0, 0), limit, fetch)
+}
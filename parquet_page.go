@@ -0,0 +1,518 @@
+package huggingface
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// Page types of parquet files.
+const (
+	parquetDataPage       = 0
+	parquetIndexPage      = 1
+	parquetDictionaryPage = 2
+	parquetDataPageV2     = 3
+)
+
+// Encodings of parquet pages.
+const (
+	parquetEncodingPlain           = 0
+	parquetEncodingPlainDictionary = 2
+	parquetEncodingRLE             = 3
+	parquetEncodingRLEDictionary   = 8
+	parquetEncodingByteStreamSplit = 9
+)
+
+// Compression codecs of parquet column chunks.
+const (
+	parquetCodecUncompressed = 0
+	parquetCodecSnappy       = 1
+	parquetCodecGzip         = 2
+)
+
+var parquetCodecNames = []string{"UNCOMPRESSED", "SNAPPY", "GZIP", "LZO", "BROTLI", "LZ4", "ZSTD", "LZ4_RAW"}
+
+// parquetColumnData contains the levels and the non-null values of a column chunk.
+type parquetColumnData struct {
+	repetitionLevels []int16
+	definitionLevels []int16
+	values           []any
+}
+
+// numRows returns the number of rows, which start with a repetition level of zero.
+func (d *parquetColumnData) numRows() int64 {
+	if d.repetitionLevels == nil {
+		if d.definitionLevels != nil {
+			return int64(len(d.definitionLevels))
+		}
+
+		return int64(len(d.values))
+	}
+
+	var n int64
+
+	for _, level := range d.repetitionLevels {
+		if level == 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// decodeColumnChunk decodes the pages of a column chunk.
+func decodeColumnChunk(data []byte, column *parquetColumnChunk, leaf *parquetNode) (*parquetColumnData, error) {
+	result := &parquetColumnData{}
+
+	var (
+		dictionary []any
+		numValues  int64
+	)
+
+	for numValues < column.numValues && len(data) > 0 {
+		r := &thriftReader{data: data}
+
+		header, err := r.readPageHeader()
+		if err != nil {
+			return nil, err
+		}
+
+		if header.compressedPageSize < 0 || int(header.compressedPageSize) > len(data)-r.pos {
+			return nil, errThriftTruncated
+		}
+
+		if header.numValues < 0 || header.uncompressedPageSize < 0 {
+			return nil, fmt.Errorf("parquet: column %v: invalid page header", column.path)
+		}
+
+		page := data[r.pos : r.pos+int(header.compressedPageSize)]
+		data = data[r.pos+int(header.compressedPageSize):]
+
+		switch header.typ {
+		case parquetDictionaryPage:
+			page, err = decompressPage(column.codec, page, header.uncompressedPageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			dictionary, _, err = decodePlainValues(page, leaf.element, int(header.numValues))
+			if err != nil {
+				return nil, err
+			}
+		case parquetDataPage, parquetDataPageV2:
+			if err := decodeDataPage(result, header, page, column.codec, leaf, dictionary); err != nil {
+				return nil, fmt.Errorf("parquet: column %v: %w", column.path, err)
+			}
+
+			numValues += int64(header.numValues)
+		}
+	}
+
+	return result, nil
+}
+
+// decodeDataPage decodes the levels and values of a data page and appends them to the column data.
+func decodeDataPage(result *parquetColumnData, header *parquetPageHeader, page []byte, codec int32, leaf *parquetNode, dictionary []any) error {
+	n := int(header.numValues)
+
+	var (
+		repetitionLevels, definitionLevels []int16
+		err                                error
+	)
+
+	if header.typ == parquetDataPageV2 {
+		// The levels of version 2 pages are not compressed and have no length prefix
+		repLength, defLength := int(header.repetitionLevelsByteLength), int(header.definitionLevelsByteLength)
+		if repLength < 0 || defLength < 0 || repLength+defLength > len(page) {
+			return errThriftTruncated
+		}
+
+		if leaf.maxRepetitionLevel > 0 {
+			if repetitionLevels, err = decodeLevels(page[:repLength], leaf.maxRepetitionLevel, n); err != nil {
+				return err
+			}
+		}
+
+		if leaf.maxDefinitionLevel > 0 {
+			if definitionLevels, err = decodeLevels(page[repLength:repLength+defLength], leaf.maxDefinitionLevel, n); err != nil {
+				return err
+			}
+		}
+
+		page = page[repLength+defLength:]
+
+		if header.isCompressed {
+			if page, err = decompressPage(codec, page, header.uncompressedPageSize-int32(repLength+defLength)); err != nil {
+				return err
+			}
+		}
+	} else {
+		if page, err = decompressPage(codec, page, header.uncompressedPageSize); err != nil {
+			return err
+		}
+
+		if leaf.maxRepetitionLevel > 0 {
+			if repetitionLevels, page, err = decodePrefixedLevels(page, leaf.maxRepetitionLevel, n); err != nil {
+				return err
+			}
+		}
+
+		if leaf.maxDefinitionLevel > 0 {
+			if definitionLevels, page, err = decodePrefixedLevels(page, leaf.maxDefinitionLevel, n); err != nil {
+				return err
+			}
+		}
+	}
+
+	count := n
+	if definitionLevels != nil {
+		count = 0
+
+		for _, level := range definitionLevels {
+			if level == leaf.maxDefinitionLevel {
+				count++
+			}
+		}
+	}
+
+	values, err := decodeValues(page, header.encoding, leaf.element, count, dictionary)
+	if err != nil {
+		return err
+	}
+
+	result.repetitionLevels = append(result.repetitionLevels, repetitionLevels...)
+	result.definitionLevels = append(result.definitionLevels, definitionLevels...)
+	result.values = append(result.values, values...)
+
+	return nil
+}
+
+// decompressPage decompresses a page with the compression codec of the column chunk.
+func decompressPage(codec int32, page []byte, uncompressedSize int32) ([]byte, error) {
+	if uncompressedSize < 0 {
+		return nil, fmt.Errorf("parquet: invalid uncompressed page size %d", uncompressedSize)
+	}
+
+	switch codec {
+	case parquetCodecUncompressed:
+		return page, nil
+	case parquetCodecSnappy:
+		return snappyDecode(page, int(uncompressedSize))
+	case parquetCodecGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(page))
+		if err != nil {
+			return nil, err
+		}
+
+		defer zr.Close()
+
+		// Pages decompressing to more than the uncompressed size of the header are corrupt
+		buf := bytes.NewBuffer(make([]byte, 0, uncompressedSize))
+		if _, err := io.Copy(buf, io.LimitReader(zr, int64(uncompressedSize)+1)); err != nil {
+			return nil, err
+		}
+
+		if buf.Len() > int(uncompressedSize) {
+			return nil, errors.New("parquet: page is larger than its uncompressed size")
+		}
+
+		return buf.Bytes(), nil
+	default:
+		if codec > 0 && int(codec) < len(parquetCodecNames) {
+			return nil, fmt.Errorf("parquet: unsupported compression codec %s", parquetCodecNames[codec])
+		}
+
+		return nil, fmt.Errorf("parquet: unsupported compression codec %d", codec)
+	}
+}
+
+// decodePrefixedLevels decodes levels stored with the RLE encoding prefixed by their length
+// and returns the remainder of the page.
+func decodePrefixedLevels(page []byte, maxLevel int16, n int) ([]int16, []byte, error) {
+	if len(page) < 4 {
+		return nil, nil, errThriftTruncated
+	}
+
+	length := int(binary.LittleEndian.Uint32(page))
+	if length > len(page)-4 {
+		return nil, nil, errThriftTruncated
+	}
+
+	levels, err := decodeLevels(page[4:4+length], maxLevel, n)
+
+	return levels, page[4+length:], err
+}
+
+// decodeLevels decodes n levels stored with the RLE encoding.
+func decodeLevels(data []byte, maxLevel int16, n int) ([]int16, error) {
+	values, err := decodeRLEHybrid(data, bits.Len16(uint16(maxLevel)), n)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]int16, n)
+	for i, v := range values {
+		levels[i] = int16(v)
+	}
+
+	return levels, nil
+}
+
+// decodeRLEHybrid decodes n values of the specified bit width stored with the hybrid of
+// run length encoding and bit packing.
+func decodeRLEHybrid(data []byte, bitWidth, n int) ([]uint32, error) {
+	if n < 0 || bitWidth < 0 || bitWidth > 32 {
+		return nil, fmt.Errorf("parquet: invalid run length encoding of %d values with bit width %d", n, bitWidth)
+	}
+
+	// Runs may encode more values than bytes, so the capacity is only a hint bounded by the data
+	values := make([]uint32, 0, minInt(n, 8*len(data)))
+	byteWidth := (bitWidth + 7) / 8
+
+	for len(values) < n {
+		header, read := binary.Uvarint(data)
+		if read <= 0 {
+			return nil, errThriftTruncated
+		}
+
+		data = data[read:]
+
+		if header&1 == 1 {
+			// Groups of 8 bit packed values, the first value in the lowest bits
+			groups := header >> 1
+			if bitWidth > 0 && groups > uint64(len(data)/bitWidth) {
+				return nil, errThriftTruncated
+			}
+
+			size := int(groups) * bitWidth
+
+			for i := 0; uint64(i)/8 < groups && len(values) < n; i++ {
+				var v uint32
+
+				for b := 0; b < bitWidth; b++ {
+					bit := i*bitWidth + b
+					if data[bit/8]&(1<<(bit%8)) != 0 {
+						v |= 1 << b
+					}
+				}
+
+				values = append(values, v)
+			}
+
+			data = data[size:]
+		} else {
+			count := int(header >> 1)
+			if byteWidth > len(data) {
+				return nil, errThriftTruncated
+			}
+
+			var v uint32
+			for i := 0; i < byteWidth; i++ {
+				v |= uint32(data[i]) << (8 * i)
+			}
+
+			data = data[byteWidth:]
+
+			for i := 0; i < count && len(values) < n; i++ {
+				values = append(values, v)
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// decodeValues decodes count values of a data page.
+func decodeValues(data []byte, encoding int32, element parquetSchemaElement, count int, dictionary []any) ([]any, error) {
+	switch encoding {
+	case parquetEncodingPlain:
+		values, _, err := decodePlainValues(data, element, count)
+		return values, err
+	case parquetEncodingPlainDictionary, parquetEncodingRLEDictionary:
+		if count == 0 {
+			return nil, nil
+		}
+
+		if len(data) == 0 {
+			return nil, errThriftTruncated
+		}
+
+		indices, err := decodeRLEHybrid(data[1:], int(data[0]), count)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, count)
+
+		for i, index := range indices {
+			if int(index) >= len(dictionary) {
+				return nil, fmt.Errorf("dictionary index %d out of range", index)
+			}
+
+			values[i] = dictionary[index]
+		}
+
+		return values, nil
+	case parquetEncodingRLE:
+		if element.typ != parquetBoolean {
+			return nil, fmt.Errorf("unsupported encoding RLE for type %d", element.typ)
+		}
+
+		if len(data) < 4 {
+			return nil, errThriftTruncated
+		}
+
+		bools, err := decodeRLEHybrid(data[4:], 1, count)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, count)
+		for i, v := range bools {
+			values[i] = v == 1
+		}
+
+		return values, nil
+	case parquetEncodingByteStreamSplit:
+		return decodeByteStreamSplit(data, element, count)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %d", encoding)
+	}
+}
+
+// decodePlainValues decodes count values with the plain encoding and returns the number of bytes read.
+func decodePlainValues(data []byte, element parquetSchemaElement, count int) ([]any, int, error) {
+	// Each plain encoded value takes at least one bit
+	if count < 0 || count > 8*len(data) || (element.typ == parquetBoolean && len(data) < (count+7)/8) {
+		return nil, 0, errThriftTruncated
+	}
+
+	values := make([]any, count)
+	pos := 0
+
+	fixed := func(size int) ([]byte, error) {
+		if size < 0 || len(data)-pos < size {
+			return nil, errThriftTruncated
+		}
+
+		b := data[pos : pos+size]
+		pos += size
+
+		return b, nil
+	}
+
+	for i := 0; i < count; i++ {
+		switch element.typ {
+		case parquetBoolean:
+			values[i] = data[i/8]&(1<<(i%8)) != 0
+		case parquetInt32:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			values[i] = int32(binary.LittleEndian.Uint32(b))
+		case parquetInt64:
+			b, err := fixed(8)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			values[i] = int64(binary.LittleEndian.Uint64(b))
+		case parquetInt96:
+			b, err := fixed(12)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			values[i] = parquetInt96Value(b)
+		case parquetFloat:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			values[i] = math.Float32frombits(binary.LittleEndian.Uint32(b))
+		case parquetDouble:
+			b, err := fixed(8)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		case parquetByteArray:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if values[i], err = fixed(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, 0, err
+			}
+		case parquetFixedLenByteArray:
+			b, err := fixed(int(element.typeLength))
+			if err != nil {
+				return nil, 0, err
+			}
+
+			values[i] = b
+		default:
+			return nil, 0, fmt.Errorf("unsupported type %d", element.typ)
+		}
+	}
+
+	if element.typ == parquetBoolean {
+		pos = (count + 7) / 8
+	}
+
+	return values, pos, nil
+}
+
+// decodeByteStreamSplit decodes values whose bytes are split into one stream per byte position.
+func decodeByteStreamSplit(data []byte, element parquetSchemaElement, count int) ([]any, error) {
+	var size int
+
+	switch element.typ {
+	case parquetInt32, parquetFloat:
+		size = 4
+	case parquetInt64, parquetDouble:
+		size = 8
+	case parquetFixedLenByteArray:
+		size = int(element.typeLength)
+	default:
+		return nil, fmt.Errorf("unsupported encoding BYTE_STREAM_SPLIT for type %d", element.typ)
+	}
+
+	if size <= 0 || count < 0 || count > len(data)/size {
+		return nil, errThriftTruncated
+	}
+
+	joined := make([]byte, size*count)
+
+	for i := 0; i < count; i++ {
+		for b := 0; b < size; b++ {
+			joined[i*size+b] = data[b*count+i]
+		}
+	}
+
+	values, _, err := decodePlainValues(joined, element, count)
+
+	return values, err
+}
+
+// parquetInt96Value is a legacy timestamp of 8 bytes nanoseconds of the day followed by 4 bytes julian day.
+type parquetInt96Value []byte
+
+// minInt returns the smaller of the integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,98 @@
+package huggingface
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errSnappyCorrupt = errors.New("parquet: corrupt snappy data")
+
+// snappyDecode decodes a block of the snappy format, the default compression of parquet files.
+// Blocks decoding to more than maxSize bytes are rejected.
+func snappyDecode(src []byte, maxSize int) ([]byte, error) {
+	n, read := binary.Uvarint(src)
+	if read <= 0 || n > uint64(maxSize) {
+		return nil, errSnappyCorrupt
+	}
+
+	dst := make([]byte, 0, n)
+	src = src[read:]
+
+	for len(src) > 0 {
+		tag := src[0]
+
+		var length, offset int
+
+		switch tag & 0x03 {
+		case 0:
+			// Literal, whose length is stored in the tag or in the following 1 to 4 bytes
+			length = int(tag >> 2)
+			src = src[1:]
+
+			if length >= 60 {
+				extra := length - 59
+				if len(src) < extra {
+					return nil, errSnappyCorrupt
+				}
+
+				length = 0
+				for i := extra - 1; i >= 0; i-- {
+					length = length<<8 | int(src[i])
+				}
+
+				src = src[extra:]
+			}
+
+			length++
+
+			if length <= 0 || len(src) < length || uint64(len(dst)+length) > n {
+				return nil, errSnappyCorrupt
+			}
+
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+
+			continue
+		case 1:
+			if len(src) < 2 {
+				return nil, errSnappyCorrupt
+			}
+
+			length = 4 + int(tag>>2&0x07)
+			offset = int(tag>>5)<<8 | int(src[1])
+			src = src[2:]
+		case 2:
+			if len(src) < 3 {
+				return nil, errSnappyCorrupt
+			}
+
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src[1:]))
+			src = src[3:]
+		case 3:
+			if len(src) < 5 {
+				return nil, errSnappyCorrupt
+			}
+
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src[1:]))
+			src = src[5:]
+		}
+
+		if offset <= 0 || offset > len(dst) || uint64(len(dst)+length) > n {
+			return nil, errSnappyCorrupt
+		}
+
+		// Copies may overlap with the bytes they produce, so they are appended byte by byte
+		start := len(dst) - offset
+		for i := 0; i < length; i++ {
+			dst = append(dst, dst[start+i])
+		}
+	}
+
+	if uint64(len(dst)) != n {
+		return nil, errSnappyCorrupt
+	}
+
+	return dst, nil
+}
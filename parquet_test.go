@@ -0,0 +1,656 @@
+package huggingface
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// thriftWriter encodes values of the thrift compact protocol for the parquet test files.
+type thriftWriter struct {
+	bytes.Buffer
+	lastIDs []int16
+}
+
+func (w *thriftWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *thriftWriter) structEnd() {
+	w.WriteByte(0)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.WriteByte(typ)
+		w.varint(int64(id))
+	}
+
+	*last = id
+}
+
+func (w *thriftWriter) varint(v int64) {
+	w.Write(binary.AppendVarint(nil, v))
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(v []byte) {
+	w.Write(binary.AppendUvarint(nil, uint64(len(v))))
+	w.Write(v)
+}
+
+func (w *thriftWriter) list(typ byte, n int) {
+	if n < 15 {
+		w.WriteByte(byte(n)<<4 | typ)
+		return
+	}
+
+	w.WriteByte(0xf0 | typ)
+	w.Write(binary.AppendUvarint(nil, uint64(n)))
+}
+
+// testParquetColumn is a column chunk of a parquet test file.
+type testParquetColumn struct {
+	path     []string
+	typ      int32
+	maxRep   int16
+	maxDef   int16
+	reps     []int16
+	defs     []int16
+	values   []byte
+	encoding int32
+	dict     []byte
+	numDict  int
+	codec    int32
+	v2       bool
+}
+
+// testParquetElement is a schema element of a parquet test file.
+type testParquetElement struct {
+	name        string
+	typ         int32
+	repetition  int32
+	numChildren int32
+	converted   int32
+	logical     int16
+}
+
+// rleLevels encodes levels as runs of length one.
+func rleLevels(levels []int16) []byte {
+	buf := []byte{}
+	for _, level := range levels {
+		buf = append(buf, 2, byte(level))
+	}
+
+	return buf
+}
+
+func plainInt32(values ...int32) []byte {
+	buf := []byte{}
+	for _, v := range values {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(v))
+	}
+
+	return buf
+}
+
+func plainInt64(values ...int64) []byte {
+	buf := []byte{}
+	for _, v := range values {
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(v))
+	}
+
+	return buf
+}
+
+func plainStrings(values ...string) []byte {
+	buf := []byte{}
+	for _, v := range values {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(v)))
+		buf = append(buf, v...)
+	}
+
+	return buf
+}
+
+// snappyLiteral encodes the data as a single snappy literal.
+func snappyLiteral(data []byte) []byte {
+	buf := binary.AppendUvarint(nil, uint64(len(data)))
+	buf = append(buf, 60<<2, byte(len(data)-1))
+
+	return append(buf, data...)
+}
+
+func compressTestPage(codec int32, data []byte) []byte {
+	switch codec {
+	case parquetCodecSnappy:
+		return snappyLiteral(data)
+	case parquetCodecGzip:
+		buf := &bytes.Buffer{}
+		zw := gzip.NewWriter(buf)
+		_, _ = zw.Write(data)
+		_ = zw.Close()
+
+		return buf.Bytes()
+	default:
+		return data
+	}
+}
+
+// writeTestPage writes the header and the data of a page.
+func writeTestPage(file *bytes.Buffer, typ int32, uncompressed, data []byte, header func(w *thriftWriter)) {
+	w := &thriftWriter{}
+	w.structBegin()
+	w.i32Field(1, typ)
+	w.i32Field(2, int32(len(uncompressed)))
+	w.i32Field(3, int32(len(data)))
+	header(w)
+	w.structEnd()
+
+	file.Write(w.Bytes())
+	file.Write(data)
+}
+
+// writeTestColumn writes the pages of a column chunk and its metadata.
+func writeTestColumn(file *bytes.Buffer, meta *thriftWriter, column testParquetColumn) {
+	start := int64(file.Len())
+	dictOffset := int64(-1)
+	numValues := len(column.defs)
+
+	if numValues == 0 {
+		numValues = len(column.values) / 8
+	}
+
+	if column.dict != nil {
+		dictOffset = start
+		writeTestPage(file, parquetDictionaryPage, column.dict, compressTestPage(column.codec, column.dict), func(w *thriftWriter) {
+			w.field(7, thriftStruct)
+			w.structBegin()
+			w.i32Field(1, int32(column.numDict))
+			w.i32Field(2, parquetEncodingPlain)
+			w.structEnd()
+		})
+	}
+
+	dataOffset := int64(file.Len())
+
+	if column.v2 {
+		repLevels, defLevels := []byte{}, []byte{}
+		if column.maxRep > 0 {
+			repLevels = rleLevels(column.reps)
+		}
+
+		if column.maxDef > 0 {
+			defLevels = rleLevels(column.defs)
+		}
+
+		uncompressed := append(append(append([]byte{}, repLevels...), defLevels...), column.values...)
+		data := append(append(append([]byte{}, repLevels...), defLevels...), compressTestPage(column.codec, column.values)...)
+
+		writeTestPage(file, parquetDataPageV2, uncompressed, data, func(w *thriftWriter) {
+			w.field(8, thriftStruct)
+			w.structBegin()
+			w.i32Field(1, int32(numValues))
+			w.i32Field(2, 0)
+			w.i32Field(3, 3)
+			w.i32Field(4, column.encoding)
+			w.i32Field(5, int32(len(defLevels)))
+			w.i32Field(6, int32(len(repLevels)))
+			w.field(7, thriftBooleanTrue)
+			w.structEnd()
+		})
+	} else {
+		uncompressed := []byte{}
+
+		for _, levels := range []struct {
+			max    int16
+			levels []int16
+		}{{column.maxRep, column.reps}, {column.maxDef, column.defs}} {
+			if levels.max > 0 {
+				encoded := rleLevels(levels.levels)
+				uncompressed = binary.LittleEndian.AppendUint32(uncompressed, uint32(len(encoded)))
+				uncompressed = append(uncompressed, encoded...)
+			}
+		}
+
+		uncompressed = append(uncompressed, column.values...)
+
+		writeTestPage(file, parquetDataPage, uncompressed, compressTestPage(column.codec, uncompressed), func(w *thriftWriter) {
+			w.field(5, thriftStruct)
+			w.structBegin()
+			w.i32Field(1, int32(numValues))
+			w.i32Field(2, column.encoding)
+			w.i32Field(3, parquetEncodingRLE)
+			w.i32Field(4, parquetEncodingRLE)
+			w.structEnd()
+		})
+	}
+
+	meta.structBegin()
+	meta.i64Field(2, start)
+	meta.field(3, thriftStruct)
+	meta.structBegin()
+	meta.i32Field(1, column.typ)
+	meta.field(2, thriftList)
+	meta.list(thriftI32, 1)
+	meta.varint(int64(column.encoding))
+	meta.field(3, thriftList)
+	meta.list(thriftBinary, len(column.path))
+
+	for _, name := range column.path {
+		meta.binary([]byte(name))
+	}
+
+	meta.i32Field(4, column.codec)
+	meta.i64Field(5, int64(numValues))
+	meta.i64Field(6, int64(file.Len())-start)
+	meta.i64Field(7, int64(file.Len())-start)
+	meta.i64Field(9, dataOffset)
+
+	if dictOffset >= 0 {
+		meta.i64Field(11, dictOffset)
+	}
+
+	meta.structEnd()
+	meta.structEnd()
+}
+
+// newTestParquetFile creates a parquet file with two row groups of three rows, whose schema is
+//
+//	required int64 id;
+//	optional binary text (STRING);
+//	optional group tags (LIST) { repeated group list { optional binary element (STRING); } }
+//	optional group meta { required int32 a; optional double b; }
+//	optional group spans (LIST) { repeated group list { optional group element { required int32 start; required int32 end; } } }
+func newTestParquetFile() []byte {
+	const none = -1
+
+	schema := []testParquetElement{
+		{name: "schema", typ: none, numChildren: 5, converted: none},
+		{name: "id", typ: parquetInt64, repetition: parquetRequired, converted: none},
+		{name: "text", typ: parquetByteArray, repetition: parquetOptional, converted: parquetConvertedUTF8, logical: parquetLogicalString},
+		{name: "tags", typ: none, repetition: parquetOptional, numChildren: 1, converted: parquetConvertedList, logical: parquetLogicalList},
+		{name: "list", typ: none, repetition: parquetRepeated, numChildren: 1, converted: none},
+		{name: "element", typ: parquetByteArray, repetition: parquetOptional, converted: parquetConvertedUTF8},
+		{name: "meta", typ: none, repetition: parquetOptional, numChildren: 2, converted: none},
+		{name: "a", typ: parquetInt32, repetition: parquetRequired, converted: none},
+		{name: "b", typ: parquetDouble, repetition: parquetOptional, converted: none},
+		{name: "spans", typ: none, repetition: parquetOptional, numChildren: 1, converted: parquetConvertedList},
+		{name: "list", typ: none, repetition: parquetRepeated, numChildren: 1, converted: none},
+		{name: "element", typ: none, repetition: parquetOptional, numChildren: 2, converted: none},
+		{name: "start", typ: parquetInt32, repetition: parquetRequired, converted: none},
+		{name: "end", typ: parquetInt32, repetition: parquetRequired, converted: none},
+	}
+
+	file := bytes.NewBufferString(parquetMagic)
+
+	meta := &thriftWriter{}
+	meta.structBegin()
+	meta.i32Field(1, 1)
+	meta.field(2, thriftList)
+	meta.list(thriftStruct, len(schema))
+
+	for _, element := range schema {
+		meta.structBegin()
+
+		if element.typ != none {
+			meta.i32Field(1, element.typ)
+		}
+
+		if element.name != "schema" {
+			meta.i32Field(3, element.repetition)
+		}
+
+		meta.field(4, thriftBinary)
+		meta.binary([]byte(element.name))
+
+		if element.numChildren > 0 {
+			meta.i32Field(5, element.numChildren)
+		}
+
+		if element.converted != none {
+			meta.i32Field(6, element.converted)
+		}
+
+		if element.logical != 0 {
+			meta.field(10, thriftStruct)
+			meta.structBegin()
+			meta.field(element.logical, thriftStruct)
+			meta.structBegin()
+			meta.structEnd()
+			meta.structEnd()
+		}
+
+		meta.structEnd()
+	}
+
+	meta.i64Field(3, 6)
+	meta.field(4, thriftList)
+	meta.list(thriftStruct, 2)
+
+	for rowGroup := int64(0); rowGroup < 2; rowGroup++ {
+		first := 3*rowGroup + 1
+
+		columns := []testParquetColumn{
+			{path: []string{"id"}, typ: parquetInt64, values: plainInt64(first, first+1, first+2)},
+			{
+				path: []string{"text"}, typ: parquetByteArray, maxDef: 1, defs: []int16{1, 0, 1},
+				dict: plainStrings("hello"), numDict: 1, encoding: parquetEncodingRLEDictionary,
+				// Bit width 0 followed by a run of two zero indices
+				values: []byte{0, 4}, codec: parquetCodecSnappy,
+			},
+			{
+				path: []string{"tags", "list", "element"}, typ: parquetByteArray, maxRep: 1, maxDef: 3,
+				reps: []int16{0, 1, 0, 0}, defs: []int16{3, 3, 1, 0}, values: plainStrings("a", "b"), v2: true, codec: parquetCodecSnappy,
+			},
+			{path: []string{"meta", "a"}, typ: parquetInt32, maxDef: 1, defs: []int16{1, 0, 1}, values: plainInt32(1, 3)},
+			{
+				path: []string{"meta", "b"}, typ: parquetDouble, maxDef: 2, defs: []int16{2, 0, 1},
+				values: binary.LittleEndian.AppendUint64(nil, math.Float64bits(0.5)), codec: parquetCodecGzip,
+			},
+			{
+				path: []string{"spans", "list", "element", "start"}, typ: parquetInt32, maxRep: 1, maxDef: 3,
+				reps: []int16{0, 1, 0, 0}, defs: []int16{3, 3, 0, 3}, values: plainInt32(0, 6, 1),
+			},
+			{
+				path: []string{"spans", "list", "element", "end"}, typ: parquetInt32, maxRep: 1, maxDef: 3,
+				reps: []int16{0, 1, 0, 0}, defs: []int16{3, 3, 0, 3}, values: plainInt32(5, 11, 2),
+			},
+		}
+
+		meta.structBegin()
+		meta.field(1, thriftList)
+		meta.list(thriftStruct, len(columns))
+
+		for _, column := range columns {
+			writeTestColumn(file, meta, column)
+		}
+
+		meta.i64Field(2, 0)
+		meta.i64Field(3, 3)
+		meta.structEnd()
+	}
+
+	meta.structEnd()
+
+	file.Write(meta.Bytes())
+	_ = binary.Write(file, binary.LittleEndian, uint32(meta.Len()))
+	file.WriteString(parquetMagic)
+
+	return file.Bytes()
+}
+
+func TestParquetReader(t *testing.T) {
+	data := newTestParquetFile()
+
+	pr, err := NewParquetReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), pr.NumRows())
+	assert.Equal(t, 2, pr.NumRowGroups())
+
+	columns := pr.Columns()
+	assert.Len(t, columns, 7)
+	assert.Equal(t, ParquetColumn{Path: []string{"text"}, Type: "BYTE_ARRAY", LogicalType: "STRING", Optional: true}, columns[1])
+	assert.Equal(t, []string{"spans", "list", "element", "end"}, columns[6].Path)
+
+	t.Run("Row group", func(t *testing.T) {
+		rows, err := pr.ReadRowGroup(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]any{
+			{
+				"id":    int64(4),
+				"text":  "hello",
+				"tags":  []any{"a", "b"},
+				"meta":  map[string]any{"a": int32(1), "b": 0.5},
+				"spans": []any{map[string]any{"start": int32(0), "end": int32(5)}, map[string]any{"start": int32(6), "end": int32(11)}},
+			},
+			{"id": int64(5), "text": nil, "tags": []any{}, "meta": nil, "spans": nil},
+			{
+				"id":    int64(6),
+				"text":  "hello",
+				"tags":  nil,
+				"meta":  map[string]any{"a": int32(3), "b": nil},
+				"spans": []any{map[string]any{"start": int32(1), "end": int32(2)}},
+			},
+		}, rows)
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		rows, err := pr.ReadRowGroup(0, "id", "tags")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": int64(1), "tags": []any{"a", "b"}}, rows[0])
+
+		_, err = pr.ReadRowGroup(0, "unknown")
+		assert.EqualError(t, err, `parquet: unknown column "unknown"`)
+	})
+
+	t.Run("Rows", func(t *testing.T) {
+		rows, err := pr.Rows(context.Background(), "id", "text").All()
+		assert.NoError(t, err)
+		assert.Len(t, rows, 6)
+		assert.Equal(t, int64(5), rows[5].RowIdx)
+
+		row := struct {
+			ID   int64   `json:"id"`
+			Text *string `json:"text"`
+		}{}
+
+		assert.NoError(t, rows[4].Decode(&row))
+		assert.Equal(t, int64(5), row.ID)
+		assert.Nil(t, row.Text)
+	})
+
+	t.Run("Invalid number of rows", func(t *testing.T) {
+		for _, numRows := range []int64{-1, 1 << 40} {
+			invalid, err := NewParquetReader(bytes.NewReader(data), int64(len(data)))
+			assert.NoError(t, err)
+
+			invalid.metadata.rowGroups[0].numRows = numRows
+
+			_, err = invalid.ReadRowGroup(0)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Short read", func(t *testing.T) {
+		short, err := NewParquetReader(bytes.NewReader(data), int64(len(data)))
+		assert.NoError(t, err)
+
+		short.r = readerAtFunc(func(p []byte, off int64) (int, error) {
+			return copy(p, data[off:off+int64(len(p))/2]), io.EOF
+		})
+
+		_, err = short.ReadRowGroup(0, "id")
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+// readerAtFunc is an adapter to use a function as io.ReaderAt.
+type readerAtFunc func(p []byte, off int64) (int, error)
+
+func (f readerAtFunc) ReadAt(p []byte, off int64) (int, error) {
+	return f(p, off)
+}
+
+func TestParquetReaderPyarrow(t *testing.T) {
+	// The fixture is written by testdata/generate_parquet.py
+	data, err := os.ReadFile("testdata/pyarrow.parquet")
+	if os.IsNotExist(err) {
+		t.Skip("testdata/pyarrow.parquet is missing, run testdata/generate_parquet.py to write it")
+	}
+
+	assert.NoError(t, err)
+
+	pr, err := NewParquetReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pr.NumRows())
+
+	columns := pr.Columns()
+	assert.Len(t, columns, 4)
+	assert.Equal(t, ParquetColumn{Path: []string{"label"}, Type: "BYTE_ARRAY", LogicalType: "STRING", Optional: true}, columns[1])
+	assert.Equal(t, []string{"tags", "list", "element"}, columns[3].Path)
+
+	rows, err := pr.ReadRowGroup(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{"id": int64(1), "label": "positive", "score": 0.5, "tags": []any{"a", "b"}},
+		{"id": int64(2), "label": "negative", "score": nil, "tags": []any{}},
+		{"id": int64(3), "label": "positive", "score": 1.25, "tags": nil},
+	}, rows)
+}
+
+func TestParquetRows(t *testing.T) {
+	data := newTestParquetFile()
+
+	var listRequests, rangeRequests int32
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/datasets/org/data/parquet/default/train":
+			atomic.AddInt32(&listRequests, 1)
+			fmt.Fprintf(w, `["%s/files/0.parquet","%s/files/1.parquet"]`, server.URL, server.URL)
+		case "/files/0.parquet", "/files/1.parquet":
+			atomic.AddInt32(&rangeRequests, 1)
+			assert.NotEmpty(t, r.Header.Get("Range"))
+			http.ServeContent(w, r, "data.parquet", time.Time{}, bytes.NewReader(data))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewHubClient("", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	t.Run("Offset and limit", func(t *testing.T) {
+		atomic.StoreInt32(&rangeRequests, 0)
+
+		it := client.ParquetRows(context.Background(), &ParquetRowsRequest{
+			RepoID:  "org/data",
+			Config:  "default",
+			Split:   "train",
+			Columns: []string{"id"},
+			Offset:  4,
+			Limit:   4,
+		})
+
+		// The files are listed when iterating
+		assert.Equal(t, int32(0), atomic.LoadInt32(&listRequests))
+
+		rows, err := it.All()
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&listRequests))
+		assert.Len(t, rows, 4)
+		assert.Equal(t, int64(4), rows[0].RowIdx)
+		assert.Equal(t, int64(5), rows[0].Row["id"])
+		assert.Equal(t, int64(2), rows[3].Row["id"])
+		assert.Equal(t, int64(7), rows[3].RowIdx)
+
+		// The small files are read completely with the requests for their tails
+		assert.Equal(t, int32(2), atomic.LoadInt32(&rangeRequests))
+	})
+
+	t.Run("Column chunks", func(t *testing.T) {
+		atomic.StoreInt32(&rangeRequests, 0)
+
+		r := &httpRangeReader{ctx: context.Background(), transport: &client.transport, url: server.URL + "/files/0.parquet"}
+		assert.NoError(t, r.readTail(16))
+		assert.Equal(t, int64(len(data)), r.size)
+
+		pr, err := NewParquetReader(r, r.size)
+		assert.NoError(t, err)
+
+		rows, err := pr.ReadRowGroup(1, "id", "text")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": int64(6), "text": "hello"}, rows[2])
+
+		// The tail, the footer and the chunks of both columns
+		assert.Equal(t, int32(4), atomic.LoadInt32(&rangeRequests))
+	})
+
+	t.Run("Missing split", func(t *testing.T) {
+		_, err := client.ParquetRows(context.Background(), &ParquetRowsRequest{RepoID: "org/data", Config: "default"}).All()
+		assert.EqualError(t, err, "split is required")
+	})
+}
+
+func TestDecodeRLEHybrid(t *testing.T) {
+	// Bit packed values 0 to 7 with a width of 3 bits followed by a run of five 4s
+	values, err := decodeRLEHybrid([]byte{3, 0x88, 0xc6, 0xfa, 10, 4}, 3, 13)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 4, 4, 4, 4, 4}, values)
+
+	_, err = decodeRLEHybrid([]byte{3, 0x88}, 3, 8)
+	assert.Error(t, err)
+}
+
+func TestSnappyDecode(t *testing.T) {
+	// A literal abc followed by a copy of 9 bytes at offset 3
+	decoded, err := snappyDecode([]byte{12, 2 << 2, 'a', 'b', 'c', 5<<2 | 1, 3}, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("abc", 4), string(decoded))
+
+	_, err = snappyDecode([]byte{12, 2 << 2, 'a', 'b', 'c'}, 12)
+	assert.Error(t, err)
+
+	// The decoded length exceeds the uncompressed size of the page
+	_, err = snappyDecode([]byte{12, 2 << 2, 'a', 'b', 'c', 5<<2 | 1, 3}, 11)
+	assert.Error(t, err)
+}
+
+func FuzzParquet(f *testing.F) {
+	data := newTestParquetFile()
+
+	f.Add(data)
+	f.Add(data[:len(data)/2])
+
+	// Corrupts single bytes of the pages and the footer
+	for _, i := range []int{4, 20, 60, len(data) / 2, len(data) - 40, len(data) - 9} {
+		corrupt := bytes.Clone(data)
+		corrupt[i] ^= 0xff
+		f.Add(corrupt)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pr, err := NewParquetReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return
+		}
+
+		_ = pr.Columns()
+
+		for i := 0; i < pr.NumRowGroups(); i++ {
+			_, _ = pr.ReadRowGroup(i)
+		}
+	})
+}
@@ -0,0 +1,539 @@
+package huggingface
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Types of the thrift compact protocol, which encodes the metadata of parquet files.
+const (
+	thriftBooleanTrue  = 1
+	thriftBooleanFalse = 2
+	thriftByte         = 3
+	thriftI16          = 4
+	thriftI32          = 5
+	thriftI64          = 6
+	thriftDouble       = 7
+	thriftBinary       = 8
+	thriftList         = 9
+	thriftSet          = 10
+	thriftMap          = 11
+	thriftStruct       = 12
+)
+
+var errThriftTruncated = errors.New("parquet: truncated thrift data")
+
+// thriftReader decodes values of the thrift compact protocol.
+type thriftReader struct {
+	data []byte
+	pos  int
+
+	// The value of the boolean field whose header was read last, which the compact protocol
+	// stores in the type of the field header.
+	boolValue bool
+}
+
+func (r *thriftReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errThriftTruncated
+	}
+
+	b := r.data[r.pos]
+	r.pos++
+
+	return b, nil
+}
+
+func (r *thriftReader) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftTruncated
+	}
+
+	r.pos += n
+
+	return v, nil
+}
+
+func (r *thriftReader) readVarint() (int64, error) {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftTruncated
+	}
+
+	r.pos += n
+
+	return v, nil
+}
+
+func (r *thriftReader) readI32() (int32, error) {
+	v, err := r.readVarint()
+	return int32(v), err
+}
+
+func (r *thriftReader) readI64() (int64, error) {
+	return r.readVarint()
+}
+
+func (r *thriftReader) readDouble() (float64, error) {
+	if len(r.data)-r.pos < 8 {
+		return 0, errThriftTruncated
+	}
+
+	v := math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+	r.pos += 8
+
+	return v, nil
+}
+
+func (r *thriftReader) readBinary() ([]byte, error) {
+	n, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(r.data)-r.pos) < n {
+		return nil, errThriftTruncated
+	}
+
+	v := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+
+	return v, nil
+}
+
+func (r *thriftReader) readString() (string, error) {
+	v, err := r.readBinary()
+	return string(v), err
+}
+
+// readStruct reads the fields of a struct and calls fn with the id and type of each field.
+// The function must read the value of the field or skip it.
+func (r *thriftReader) readStruct(fn func(id int16, typ byte) error) error {
+	var lastID int16
+
+	for {
+		header, err := r.readByte()
+		if err != nil {
+			return err
+		}
+
+		if header == 0 {
+			return nil
+		}
+
+		typ := header & 0x0f
+
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			v, err := r.readVarint()
+			if err != nil {
+				return err
+			}
+
+			id = int16(v)
+		}
+
+		lastID = id
+
+		if typ == thriftBooleanTrue || typ == thriftBooleanFalse {
+			r.boolValue = typ == thriftBooleanTrue
+		}
+
+		if err := fn(id, typ); err != nil {
+			return err
+		}
+	}
+}
+
+// readList reads the header of a list and calls fn for each element.
+func (r *thriftReader) readList(fn func(typ byte) error) error {
+	header, err := r.readByte()
+	if err != nil {
+		return err
+	}
+
+	size := int(header >> 4)
+	if size == 15 {
+		n, err := r.readUvarint()
+		if err != nil {
+			return err
+		}
+
+		if n > uint64(len(r.data)) {
+			return errThriftTruncated
+		}
+
+		size = int(n)
+	}
+
+	for i := 0; i < size; i++ {
+		if err := fn(header & 0x0f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skip skips a value of the specified type.
+func (r *thriftReader) skip(typ byte) error {
+	var err error
+
+	switch typ {
+	case thriftBooleanTrue, thriftBooleanFalse:
+	case thriftByte:
+		_, err = r.readByte()
+	case thriftI16, thriftI32, thriftI64:
+		_, err = r.readVarint()
+	case thriftDouble:
+		_, err = r.readDouble()
+	case thriftBinary:
+		_, err = r.readBinary()
+	case thriftList, thriftSet:
+		err = r.readList(func(elemType byte) error {
+			if elemType == thriftBooleanTrue || elemType == thriftBooleanFalse {
+				_, err := r.readByte()
+				return err
+			}
+
+			return r.skip(elemType)
+		})
+	case thriftMap:
+		err = r.skipMap()
+	case thriftStruct:
+		err = r.readStruct(func(_ int16, typ byte) error {
+			return r.skip(typ)
+		})
+	default:
+		err = fmt.Errorf("parquet: invalid thrift type %d", typ)
+	}
+
+	return err
+}
+
+func (r *thriftReader) skipMap() error {
+	size, err := r.readUvarint()
+	if err != nil || size == 0 {
+		return err
+	}
+
+	types, err := r.readByte()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < size; i++ {
+		if err := r.skip(types >> 4); err != nil {
+			return err
+		}
+
+		if err := r.skip(types & 0x0f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parquetFileMetaData is the footer of a parquet file.
+type parquetFileMetaData struct {
+	schema    []parquetSchemaElement
+	numRows   int64
+	rowGroups []parquetRowGroup
+}
+
+// parquetSchemaElement is an element of the flattened schema tree of a parquet file.
+type parquetSchemaElement struct {
+	typ            int32
+	typeLength     int32
+	repetitionType int32
+	name           string
+	numChildren    int32
+	convertedType  int32
+	scale          int32
+	precision      int32
+
+	// The logical type, e.g. parquetLogicalString. The unit of timestamps and times is stored in timeUnit.
+	logicalType int16
+	timeUnit    int16
+}
+
+type parquetRowGroup struct {
+	columns []parquetColumnChunk
+	numRows int64
+}
+
+type parquetColumnChunk struct {
+	typ                  int32
+	path                 []string
+	codec                int32
+	numValues            int64
+	totalCompressedSize  int64
+	dataPageOffset       int64
+	dictionaryPageOffset int64
+}
+
+type parquetPageHeader struct {
+	typ                  int32
+	uncompressedPageSize int32
+	compressedPageSize   int32
+
+	numValues int32
+	encoding  int32
+
+	// Only set for data pages of version 2.
+	numRows                    int32
+	definitionLevelsByteLength int32
+	repetitionLevelsByteLength int32
+	isCompressed               bool
+}
+
+// readFileMetaData decodes the footer of a parquet file.
+func (r *thriftReader) readFileMetaData() (*parquetFileMetaData, error) {
+	md := &parquetFileMetaData{}
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		switch id {
+		case 2:
+			return r.readList(func(byte) error {
+				element, err := r.readSchemaElement()
+				if err != nil {
+					return err
+				}
+
+				md.schema = append(md.schema, element)
+
+				return nil
+			})
+		case 3:
+			v, err := r.readI64()
+			md.numRows = v
+
+			return err
+		case 4:
+			return r.readList(func(byte) error {
+				rowGroup, err := r.readRowGroup()
+				if err != nil {
+					return err
+				}
+
+				md.rowGroups = append(md.rowGroups, rowGroup)
+
+				return nil
+			})
+		default:
+			return r.skip(typ)
+		}
+	})
+
+	return md, err
+}
+
+func (r *thriftReader) readSchemaElement() (parquetSchemaElement, error) {
+	element := parquetSchemaElement{typ: -1, convertedType: -1}
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		var err error
+
+		switch id {
+		case 1:
+			element.typ, err = r.readI32()
+		case 2:
+			element.typeLength, err = r.readI32()
+		case 3:
+			element.repetitionType, err = r.readI32()
+		case 4:
+			element.name, err = r.readString()
+		case 5:
+			element.numChildren, err = r.readI32()
+		case 6:
+			element.convertedType, err = r.readI32()
+		case 7:
+			element.scale, err = r.readI32()
+		case 8:
+			element.precision, err = r.readI32()
+		case 10:
+			err = r.readLogicalType(&element)
+		default:
+			err = r.skip(typ)
+		}
+
+		return err
+	})
+
+	return element, err
+}
+
+// readLogicalType decodes the union of logical types, keeping the id of the set member.
+func (r *thriftReader) readLogicalType(element *parquetSchemaElement) error {
+	return r.readStruct(func(id int16, typ byte) error {
+		element.logicalType = id
+
+		switch id {
+		case parquetLogicalDecimal:
+			return r.readStruct(func(id int16, typ byte) error {
+				var err error
+
+				switch id {
+				case 1:
+					element.scale, err = r.readI32()
+				case 2:
+					element.precision, err = r.readI32()
+				default:
+					err = r.skip(typ)
+				}
+
+				return err
+			})
+		case parquetLogicalTime, parquetLogicalTimestamp:
+			return r.readStruct(func(id int16, typ byte) error {
+				if id != 2 {
+					return r.skip(typ)
+				}
+
+				return r.readStruct(func(id int16, typ byte) error {
+					element.timeUnit = id
+					return r.skip(typ)
+				})
+			})
+		default:
+			return r.skip(typ)
+		}
+	})
+}
+
+func (r *thriftReader) readRowGroup() (parquetRowGroup, error) {
+	rowGroup := parquetRowGroup{}
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		switch id {
+		case 1:
+			return r.readList(func(byte) error {
+				column, err := r.readColumnChunk()
+				if err != nil {
+					return err
+				}
+
+				rowGroup.columns = append(rowGroup.columns, column)
+
+				return nil
+			})
+		case 3:
+			v, err := r.readI64()
+			rowGroup.numRows = v
+
+			return err
+		default:
+			return r.skip(typ)
+		}
+	})
+
+	return rowGroup, err
+}
+
+func (r *thriftReader) readColumnChunk() (parquetColumnChunk, error) {
+	column := parquetColumnChunk{dictionaryPageOffset: -1}
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		if id != 3 {
+			return r.skip(typ)
+		}
+
+		return r.readStruct(func(id int16, typ byte) error {
+			var err error
+
+			switch id {
+			case 1:
+				column.typ, err = r.readI32()
+			case 3:
+				err = r.readList(func(byte) error {
+					name, err := r.readString()
+					column.path = append(column.path, name)
+
+					return err
+				})
+			case 4:
+				column.codec, err = r.readI32()
+			case 5:
+				column.numValues, err = r.readI64()
+			case 7:
+				column.totalCompressedSize, err = r.readI64()
+			case 9:
+				column.dataPageOffset, err = r.readI64()
+			case 11:
+				column.dictionaryPageOffset, err = r.readI64()
+			default:
+				err = r.skip(typ)
+			}
+
+			return err
+		})
+	})
+
+	return column, err
+}
+
+func (r *thriftReader) readPageHeader() (*parquetPageHeader, error) {
+	header := &parquetPageHeader{isCompressed: true}
+
+	err := r.readStruct(func(id int16, typ byte) error {
+		var err error
+
+		switch id {
+		case 1:
+			header.typ, err = r.readI32()
+		case 2:
+			header.uncompressedPageSize, err = r.readI32()
+		case 3:
+			header.compressedPageSize, err = r.readI32()
+		case 5, 7:
+			// The headers of data pages and dictionary pages start with the number of values and the encoding
+			err = r.readStruct(func(id int16, typ byte) error {
+				var err error
+
+				switch id {
+				case 1:
+					header.numValues, err = r.readI32()
+				case 2:
+					header.encoding, err = r.readI32()
+				default:
+					err = r.skip(typ)
+				}
+
+				return err
+			})
+		case 8:
+			err = r.readStruct(func(id int16, typ byte) error {
+				var err error
+
+				switch id {
+				case 1:
+					header.numValues, err = r.readI32()
+				case 3:
+					header.numRows, err = r.readI32()
+				case 4:
+					header.encoding, err = r.readI32()
+				case 5:
+					header.definitionLevelsByteLength, err = r.readI32()
+				case 6:
+					header.repetitionLevelsByteLength, err = r.readI32()
+				case 7:
+					header.isCompressed = r.boolValue
+				default:
+					err = r.skip(typ)
+				}
+
+				return err
+			})
+		default:
+			err = r.skip(typ)
+		}
+
+		return err
+	})
+
+	return header, err
+}
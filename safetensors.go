@@ -0,0 +1,365 @@
+package huggingface
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// maxSafetensorsHeaderSize is the maximum size of the JSON header of safetensors files.
+const maxSafetensorsHeaderSize = 100 * 1024 * 1024
+
+// maxSafetensorsWorkers is the maximum number of shards whose headers are read concurrently.
+const maxSafetensorsWorkers = 8
+
+// safetensorsIndexFile is the name of the index of sharded safetensors checkpoints.
+const safetensorsIndexFile = "model.safetensors.index.json"
+
+// safetensorsDTypeSizes contains the size in bytes of the elements of each data type.
+var safetensorsDTypeSizes = map[string]int64{
+	"BOOL": 1, "U8": 1, "I8": 1, "F8_E4M3": 1, "F8_E5M2": 1,
+	"U16": 2, "I16": 2, "F16": 2, "BF16": 2,
+	"U32": 4, "I32": 4, "F32": 4,
+	"U64": 8, "I64": 8, "F64": 8,
+}
+
+// TensorInfo describes a tensor of a safetensors file.
+type TensorInfo struct {
+	// The data type, e.g. F32, F16, BF16 or I64.
+	DType string `json:"dtype"`
+
+	// The shape, which is empty for scalars.
+	Shape []int64 `json:"shape"`
+
+	// The start and end offsets of the data of the tensor, relative to the end of the header.
+	DataOffsets [2]int64 `json:"data_offsets"`
+}
+
+// NumElements returns the number of elements of the tensor.
+func (ti TensorInfo) NumElements() int64 {
+	n := int64(1)
+	for _, dim := range ti.Shape {
+		n *= dim
+	}
+
+	return n
+}
+
+// SafetensorsFileMetadata contains the header of a safetensors file.
+type SafetensorsFileMetadata struct {
+	// The free-form metadata of the file, e.g. {"format":"pt"}.
+	Metadata map[string]string
+
+	// The tensors of the file by name.
+	Tensors map[string]TensorInfo
+
+	// The number of parameters for each data type.
+	ParameterCount map[string]int64
+
+	// The size of the JSON header in bytes. The data of the tensors starts at offset 8 + HeaderSize.
+	HeaderSize int64
+}
+
+// TensorNames returns the names of the tensors in the order of their data.
+func (m *SafetensorsFileMetadata) TensorNames() []string {
+	names := make([]string, 0, len(m.Tensors))
+	for name := range m.Tensors {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		oi, oj := m.Tensors[names[i]].DataOffsets[0], m.Tensors[names[j]].DataOffsets[0]
+		if oi != oj {
+			return oi < oj
+		}
+
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// TotalParameters returns the number of parameters of all data types.
+func (m *SafetensorsFileMetadata) TotalParameters() int64 {
+	return sumParameters(m.ParameterCount)
+}
+
+// ReadSafetensorsMetadata reads the header of the safetensors file from r. Only the length prefix
+// and the header are read, not the data of the tensors.
+func ReadSafetensorsMetadata(r io.ReaderAt) (*SafetensorsFileMetadata, error) {
+	prefix := make([]byte, 8)
+	if _, err := r.ReadAt(prefix, 0); err != nil {
+		return nil, fmt.Errorf("safetensors: reading header size: %w", err)
+	}
+
+	size := binary.LittleEndian.Uint64(prefix)
+	if size < 2 || size > maxSafetensorsHeaderSize {
+		return nil, fmt.Errorf("safetensors: invalid header size %d", size)
+	}
+
+	header := make([]byte, size)
+	if _, err := r.ReadAt(header, 8); err != nil {
+		return nil, fmt.Errorf("safetensors: reading header: %w", err)
+	}
+
+	return parseSafetensorsHeader(header)
+}
+
+// ReadSafetensorsMetadataFile reads the header of the named safetensors file.
+func ReadSafetensorsMetadataFile(name string) (*SafetensorsFileMetadata, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ReadSafetensorsMetadata(f)
+}
+
+// parseSafetensorsHeader decodes and validates the JSON header of a safetensors file.
+func parseSafetensorsHeader(header []byte) (*SafetensorsFileMetadata, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(header, &raw); err != nil {
+		return nil, fmt.Errorf("safetensors: invalid header: %w", err)
+	}
+
+	metadata := &SafetensorsFileMetadata{
+		Tensors:        make(map[string]TensorInfo, len(raw)),
+		ParameterCount: map[string]int64{},
+		HeaderSize:     int64(len(header)),
+	}
+
+	for name, value := range raw {
+		if name == "__metadata__" {
+			if err := json.Unmarshal(value, &metadata.Metadata); err != nil {
+				return nil, fmt.Errorf("safetensors: invalid metadata: %w", err)
+			}
+
+			continue
+		}
+
+		info := TensorInfo{}
+		if err := json.Unmarshal(value, &info); err != nil {
+			return nil, fmt.Errorf("safetensors: invalid tensor %q: %w", name, err)
+		}
+
+		if err := validateTensorInfo(info); err != nil {
+			return nil, fmt.Errorf("safetensors: tensor %q: %w", name, err)
+		}
+
+		metadata.Tensors[name] = info
+		metadata.ParameterCount[info.DType] += info.NumElements()
+	}
+
+	return metadata, nil
+}
+
+// validateTensorInfo checks that the data offsets of the tensor match its data type and shape.
+func validateTensorInfo(info TensorInfo) error {
+	for _, dim := range info.Shape {
+		if dim < 0 {
+			return fmt.Errorf("invalid shape %v", info.Shape)
+		}
+	}
+
+	begin, end := info.DataOffsets[0], info.DataOffsets[1]
+	if begin < 0 || end < begin {
+		return fmt.Errorf("invalid data offsets %v", info.DataOffsets)
+	}
+
+	size, ok := safetensorsDTypeSizes[info.DType]
+	if !ok {
+		return fmt.Errorf("unknown dtype %q", info.DType)
+	}
+
+	if end-begin != info.NumElements()*size {
+		return fmt.Errorf("data offsets %v do not match dtype %s and shape %v", info.DataOffsets, info.DType, info.Shape)
+	}
+
+	return nil
+}
+
+// SafetensorsRepoMetadata contains the headers of the safetensors files of a checkpoint, which is
+// either a single model.safetensors file or sharded with a model.safetensors.index.json index.
+type SafetensorsRepoMetadata struct {
+	// The metadata of the index of sharded checkpoints, e.g. {"total_size": ...}.
+	Metadata map[string]any
+
+	// Whether the checkpoint is sharded.
+	Sharded bool
+
+	// The file containing each tensor.
+	WeightMap map[string]string
+
+	// The headers of the files by name.
+	Files map[string]*SafetensorsFileMetadata
+
+	// The number of parameters for each data type.
+	ParameterCount map[string]int64
+}
+
+// TotalParameters returns the number of parameters of all data types.
+func (m *SafetensorsRepoMetadata) TotalParameters() int64 {
+	return sumParameters(m.ParameterCount)
+}
+
+// ReadSafetensorsDirMetadata reads the headers of the safetensors checkpoint in the directory,
+// e.g. a snapshot returned by SnapshotDownload. The index is followed if the checkpoint is sharded.
+func ReadSafetensorsDirMetadata(dir string) (*SafetensorsRepoMetadata, error) {
+	index, err := os.ReadFile(filepath.Join(dir, safetensorsIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		metadata, err := ReadSafetensorsMetadataFile(filepath.Join(dir, "model.safetensors"))
+		if err != nil {
+			return nil, err
+		}
+
+		return newSafetensorsRepoMetadata("model.safetensors", metadata), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return readShardedSafetensorsMetadata(index, func(filename string) (*SafetensorsFileMetadata, error) {
+		return ReadSafetensorsMetadataFile(filepath.Join(dir, filepath.FromSlash(filename)))
+	})
+}
+
+// newSafetensorsRepoMetadata creates the metadata of a checkpoint consisting of a single file.
+func newSafetensorsRepoMetadata(filename string, metadata *SafetensorsFileMetadata) *SafetensorsRepoMetadata {
+	weightMap := make(map[string]string, len(metadata.Tensors))
+	for name := range metadata.Tensors {
+		weightMap[name] = filename
+	}
+
+	parameterCount := make(map[string]int64, len(metadata.ParameterCount))
+	for dtype, n := range metadata.ParameterCount {
+		parameterCount[dtype] = n
+	}
+
+	return &SafetensorsRepoMetadata{
+		WeightMap:      weightMap,
+		Files:          map[string]*SafetensorsFileMetadata{filename: metadata},
+		ParameterCount: parameterCount,
+	}
+}
+
+// readShardedSafetensorsMetadata reads the headers of the shards listed in the index with the read
+// function, which is called concurrently.
+func readShardedSafetensorsMetadata(index []byte, read func(filename string) (*SafetensorsFileMetadata, error)) (*SafetensorsRepoMetadata, error) {
+	parsed := struct {
+		Metadata  map[string]any    `json:"metadata"`
+		WeightMap map[string]string `json:"weight_map"`
+	}{}
+
+	if err := json.Unmarshal(index, &parsed); err != nil {
+		return nil, fmt.Errorf("safetensors: invalid index: %w", err)
+	}
+
+	repoMetadata := &SafetensorsRepoMetadata{
+		Metadata:       parsed.Metadata,
+		Sharded:        true,
+		WeightMap:      parsed.WeightMap,
+		Files:          map[string]*SafetensorsFileMetadata{},
+		ParameterCount: map[string]int64{},
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxSafetensorsWorkers)
+
+	for _, filename := range shardFilenames(parsed.WeightMap) {
+		wg.Add(1)
+
+		go func(filename string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			metadata, err := read(filename)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", filename, err)
+				}
+
+				return
+			}
+
+			repoMetadata.addShard(filename, metadata)
+		}(filename)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := repoMetadata.checkWeightMap(); err != nil {
+		return nil, err
+	}
+
+	return repoMetadata, nil
+}
+
+// addShard adds the header of a shard to the metadata.
+func (m *SafetensorsRepoMetadata) addShard(filename string, metadata *SafetensorsFileMetadata) {
+	m.Files[filename] = metadata
+
+	for dtype, n := range metadata.ParameterCount {
+		m.ParameterCount[dtype] += n
+	}
+}
+
+// checkWeightMap checks that the shards contain the tensors listed in the index.
+func (m *SafetensorsRepoMetadata) checkWeightMap() error {
+	for name, filename := range m.WeightMap {
+		if _, ok := m.Files[filename].Tensors[name]; !ok {
+			return fmt.Errorf("safetensors: tensor %q is missing in %s", name, filename)
+		}
+	}
+
+	return nil
+}
+
+// shardFilenames returns the sorted unique files of the weight map of an index.
+func shardFilenames(weightMap map[string]string) []string {
+	unique := map[string]bool{}
+	filenames := []string{}
+
+	for _, filename := range weightMap {
+		if !unique[filename] {
+			unique[filename] = true
+			filenames = append(filenames, filename)
+		}
+	}
+
+	sort.Strings(filenames)
+
+	return filenames
+}
+
+func sumParameters(parameterCount map[string]int64) int64 {
+	total := int64(0)
+	for _, n := range parameterCount {
+		total += n
+	}
+
+	return total
+}
@@ -0,0 +1,160 @@
+package huggingface
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SafetensorsFile is a safetensors file mapped into memory. The tensors refer to the mapped file
+// without copying their data until they are converted to typed slices.
+type SafetensorsFile struct {
+	metadata *SafetensorsFileMetadata
+	data     []byte
+	unmap    func() error
+}
+
+// OpenSafetensors opens the named safetensors file and maps it into memory. On platforms without
+// memory mapping the file is read into memory. The file must be closed to release the mapping.
+func OpenSafetensors(name string) (*SafetensorsFile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if stat.Size() < 8 {
+		return nil, errors.New("safetensors: file is too small")
+	}
+
+	mapped, unmap, err := mapFile(f, stat.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := ReadSafetensorsMetadata(bytes.NewReader(mapped))
+	if err != nil {
+		_ = unmap()
+		return nil, err
+	}
+
+	data := mapped[8+metadata.HeaderSize:]
+
+	for name, info := range metadata.Tensors {
+		if info.DataOffsets[1] > int64(len(data)) {
+			_ = unmap()
+			return nil, fmt.Errorf("safetensors: data of tensor %q exceeds the file", name)
+		}
+	}
+
+	return &SafetensorsFile{metadata: metadata, data: data, unmap: unmap}, nil
+}
+
+// Metadata returns the header of the file.
+func (f *SafetensorsFile) Metadata() *SafetensorsFileMetadata {
+	return f.metadata
+}
+
+// Tensor returns the tensor with the specified name.
+func (f *SafetensorsFile) Tensor(name string) (*Tensor, error) {
+	info, ok := f.metadata.Tensors[name]
+	if !ok {
+		return nil, fmt.Errorf("safetensors: unknown tensor %q", name)
+	}
+
+	return &Tensor{
+		Name:  name,
+		DType: info.DType,
+		Shape: info.Shape,
+		Data:  f.data[info.DataOffsets[0]:info.DataOffsets[1]:info.DataOffsets[1]],
+	}, nil
+}
+
+// Close unmaps the file. The data of the tensors of the file must not be used afterwards.
+func (f *SafetensorsFile) Close() error {
+	return f.unmap()
+}
+
+// WriteSafetensors writes the tensors in the safetensors format with the optional metadata.
+// The data of the tensors is written in the specified order.
+func WriteSafetensors(w io.Writer, tensors []*Tensor, metadata map[string]string) error {
+	header := make(map[string]any, len(tensors)+1)
+	if len(metadata) > 0 {
+		header["__metadata__"] = metadata
+	}
+
+	offset := int64(0)
+
+	for _, t := range tensors {
+		if t.Name == "__metadata__" {
+			return fmt.Errorf("safetensors: invalid tensor name %q", t.Name)
+		}
+
+		if _, ok := header[t.Name]; ok {
+			return fmt.Errorf("safetensors: duplicate tensor %q", t.Name)
+		}
+
+		info := TensorInfo{DType: t.DType, Shape: t.Shape, DataOffsets: [2]int64{offset, offset + int64(len(t.Data))}}
+		if info.Shape == nil {
+			info.Shape = []int64{}
+		}
+
+		if err := validateTensorInfo(info); err != nil {
+			return fmt.Errorf("safetensors: tensor %q: %w", t.Name, err)
+		}
+
+		header[t.Name] = info
+		offset = info.DataOffsets[1]
+	}
+
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	// The header is padded with spaces to align the data to 8 bytes
+	if pad := len(encoded) % 8; pad != 0 {
+		encoded = append(encoded, bytes.Repeat([]byte(" "), 8-pad)...)
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, uint64(len(encoded))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(encoded); err != nil {
+		return err
+	}
+
+	for _, t := range tensors {
+		if _, err := w.Write(t.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteSafetensorsFile writes the tensors to the named safetensors file, see WriteSafetensors.
+func WriteSafetensorsFile(name string, tensors []*Tensor, metadata map[string]string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteSafetensors(f, tensors, metadata); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,145 @@
+package huggingface
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafetensorsRoundTrip(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "model.safetensors")
+
+	weights, err := NewTensor("classifier.weight", []int64{2, 3}, []float32{0.5, -1, 2, 3.25, 0, -0.125})
+	assert.NoError(t, err)
+
+	bias, err := NewTensorF16("classifier.bias", []int64{2}, []float32{1.5, -2})
+	assert.NoError(t, err)
+
+	projection, err := NewTensorBF16("projection", []int64{1, 2}, []float32{0.5, 3})
+	assert.NoError(t, err)
+
+	ids, err := NewTensor("position_ids", []int64{1, 4}, []int64{0, 1, 2, math.MaxInt64})
+	assert.NoError(t, err)
+
+	mask, err := NewTensor("mask", []int64{3}, []bool{true, false, true})
+	assert.NoError(t, err)
+
+	scale, err := NewTensor("scale", nil, []float64{0.25})
+	assert.NoError(t, err)
+
+	assert.NoError(t, WriteSafetensorsFile(name, []*Tensor{weights, bias, projection, ids, mask, scale}, map[string]string{"format": "pt"}))
+
+	f, err := OpenSafetensors(name)
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	metadata := f.Metadata()
+	assert.Equal(t, map[string]string{"format": "pt"}, metadata.Metadata)
+	assert.Equal(t, int64(0), metadata.HeaderSize%8)
+	assert.Equal(t, []string{"classifier.weight", "classifier.bias", "projection", "position_ids", "mask", "scale"}, metadata.TensorNames())
+
+	tensor, err := f.Tensor("classifier.weight")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, tensor.Shape)
+	assert.Equal(t, int64(6), tensor.NumElements())
+
+	values, err := tensor.Float32()
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0.5, -1, 2, 3.25, 0, -0.125}, values)
+
+	_, err = tensor.Int64()
+	assert.EqualError(t, err, `tensor "classifier.weight" of dtype F32 cannot be read as int64`)
+
+	tensor, err = f.Tensor("classifier.bias")
+	assert.NoError(t, err)
+	assert.Equal(t, "F16", tensor.DType)
+
+	values, err = tensor.Float32()
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1.5, -2}, values)
+
+	tensor, err = f.Tensor("projection")
+	assert.NoError(t, err)
+
+	float64s, err := tensor.Float64()
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.5, 3}, float64s)
+
+	tensor, err = f.Tensor("position_ids")
+	assert.NoError(t, err)
+
+	int64s, err := tensor.Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2, math.MaxInt64}, int64s)
+
+	tensor, err = f.Tensor("mask")
+	assert.NoError(t, err)
+
+	bools, err := tensor.Bool()
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, bools)
+
+	tensor, err = f.Tensor("scale")
+	assert.NoError(t, err)
+	assert.Empty(t, tensor.Shape)
+
+	float64s, err = tensor.Float64()
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.25}, float64s)
+
+	_, err = f.Tensor("missing")
+	assert.EqualError(t, err, `safetensors: unknown tensor "missing"`)
+}
+
+func TestWriteSafetensors(t *testing.T) {
+	a, err := NewTensor("a", []int64{2}, []int32{1, 2})
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteSafetensors(buf, []*Tensor{a}, nil))
+
+	size := binary.LittleEndian.Uint64(buf.Bytes())
+	assert.Equal(t, `{"a":{"dtype":"I32","shape":[2],"data_offsets":[0,8]}}`, string(bytes.TrimRight(buf.Bytes()[8:8+size], " ")))
+
+	assert.EqualError(t, WriteSafetensors(&bytes.Buffer{}, []*Tensor{a, a}, nil), `safetensors: duplicate tensor "a"`)
+
+	_, err = NewTensor("b", []int64{3}, []int32{1, 2})
+	assert.EqualError(t, err, `tensor "b": data offsets [0 8] do not match dtype I32 and shape [3]`)
+}
+
+func TestFloat16Conversion(t *testing.T) {
+	for f, h := range map[float32]uint16{
+		0:                     0x0000,
+		1:                     0x3c00,
+		-2:                    0xc000,
+		65504:                 0x7bff,
+		65520:                 0x7c00,
+		float32(1) / 3:        0x3555,
+		5.960464477539063e-08: 0x0001,
+		6.103515625e-05:       0x0400,
+		float32(math.Inf(-1)): 0xfc00,
+	} {
+		assert.Equal(t, h, float32ToFloat16(f), "%g", f)
+
+		if h&0x7c00 != 0x7c00 || h&0x3ff == 0 {
+			expected := f
+			if h == 0x7c00 {
+				expected = float32(math.Inf(1))
+			} else if h == 0x3555 {
+				expected = 0.333251953125
+			}
+
+			assert.Equal(t, expected, float16ToFloat32(h), "%#x", h)
+		}
+	}
+
+	assert.True(t, math.IsNaN(float64(float16ToFloat32(float32ToFloat16(float32(math.NaN()))))))
+
+	assert.Equal(t, uint16(0x3f80), float32ToBFloat16(1))
+	assert.Equal(t, uint16(0x4049), float32ToBFloat16(math.Pi))
+}
@@ -0,0 +1,341 @@
+package huggingface
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Tensor is a tensor of a safetensors file. Its data is stored in little-endian byte order.
+type Tensor struct {
+	// The name of the tensor.
+	Name string
+
+	// The data type, e.g. F32, F16, BF16 or I64.
+	DType string
+
+	// The shape, which is empty for scalars.
+	Shape []int64
+
+	// The raw data of the tensor. For tensors of a SafetensorsFile, the data refers to the mapped
+	// file and is only valid until the file is closed.
+	Data []byte
+}
+
+// NewTensor creates a tensor from a slice of float64, float32, int64, int32, int16, int8, uint64,
+// uint32, uint16, uint8 or bool values. The data type is derived from the type of the slice.
+func NewTensor(name string, shape []int64, values any) (*Tensor, error) {
+	var (
+		dtype string
+		data  []byte
+	)
+
+	switch v := values.(type) {
+	case []float64:
+		dtype, data = "F64", make([]byte, 0, 8*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(x))
+		}
+	case []float32:
+		dtype, data = "F32", make([]byte, 0, 4*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(x))
+		}
+	case []int64:
+		dtype, data = "I64", make([]byte, 0, 8*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint64(data, uint64(x))
+		}
+	case []int32:
+		dtype, data = "I32", make([]byte, 0, 4*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint32(data, uint32(x))
+		}
+	case []int16:
+		dtype, data = "I16", make([]byte, 0, 2*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint16(data, uint16(x))
+		}
+	case []int8:
+		dtype, data = "I8", make([]byte, len(v))
+		for i, x := range v {
+			data[i] = byte(x)
+		}
+	case []uint64:
+		dtype, data = "U64", make([]byte, 0, 8*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint64(data, x)
+		}
+	case []uint32:
+		dtype, data = "U32", make([]byte, 0, 4*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint32(data, x)
+		}
+	case []uint16:
+		dtype, data = "U16", make([]byte, 0, 2*len(v))
+		for _, x := range v {
+			data = binary.LittleEndian.AppendUint16(data, x)
+		}
+	case []uint8:
+		dtype, data = "U8", append([]byte{}, v...)
+	case []bool:
+		dtype, data = "BOOL", make([]byte, len(v))
+		for i, x := range v {
+			if x {
+				data[i] = 1
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tensor values %T", values)
+	}
+
+	return newTensor(name, dtype, shape, data)
+}
+
+// NewTensorF16 creates a tensor of half precision floats from float32 values.
+func NewTensorF16(name string, shape []int64, values []float32) (*Tensor, error) {
+	data := make([]byte, 0, 2*len(values))
+	for _, x := range values {
+		data = binary.LittleEndian.AppendUint16(data, float32ToFloat16(x))
+	}
+
+	return newTensor(name, "F16", shape, data)
+}
+
+// NewTensorBF16 creates a tensor of bfloat16 floats from float32 values.
+func NewTensorBF16(name string, shape []int64, values []float32) (*Tensor, error) {
+	data := make([]byte, 0, 2*len(values))
+	for _, x := range values {
+		data = binary.LittleEndian.AppendUint16(data, float32ToBFloat16(x))
+	}
+
+	return newTensor(name, "BF16", shape, data)
+}
+
+// newTensor creates a tensor and checks that the size of the data matches the shape.
+func newTensor(name, dtype string, shape []int64, data []byte) (*Tensor, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	t := &Tensor{Name: name, DType: dtype, Shape: append([]int64{}, shape...), Data: data}
+
+	info := TensorInfo{DType: dtype, Shape: t.Shape, DataOffsets: [2]int64{0, int64(len(data))}}
+	if err := validateTensorInfo(info); err != nil {
+		return nil, fmt.Errorf("tensor %q: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// NumElements returns the number of elements of the tensor.
+func (t *Tensor) NumElements() int64 {
+	return TensorInfo{Shape: t.Shape}.NumElements()
+}
+
+// Float32 returns the values of a F32, F16 or BF16 tensor as float32.
+func (t *Tensor) Float32() ([]float32, error) {
+	switch t.DType {
+	case "F32":
+		values := make([]float32, len(t.Data)/4)
+		for i := range values {
+			values[i] = math.Float32frombits(binary.LittleEndian.Uint32(t.Data[4*i:]))
+		}
+
+		return values, nil
+	case "F16":
+		values := make([]float32, len(t.Data)/2)
+		for i := range values {
+			values[i] = float16ToFloat32(binary.LittleEndian.Uint16(t.Data[2*i:]))
+		}
+
+		return values, nil
+	case "BF16":
+		values := make([]float32, len(t.Data)/2)
+		for i := range values {
+			values[i] = math.Float32frombits(uint32(binary.LittleEndian.Uint16(t.Data[2*i:])) << 16)
+		}
+
+		return values, nil
+	default:
+		return nil, t.typeError("float32")
+	}
+}
+
+// Float64 returns the values of a F64, F32, F16 or BF16 tensor as float64.
+func (t *Tensor) Float64() ([]float64, error) {
+	if t.DType != "F64" {
+		values, err := t.Float32()
+		if err != nil {
+			return nil, t.typeError("float64")
+		}
+
+		converted := make([]float64, len(values))
+		for i, x := range values {
+			converted[i] = float64(x)
+		}
+
+		return converted, nil
+	}
+
+	values := make([]float64, len(t.Data)/8)
+	for i := range values {
+		values[i] = math.Float64frombits(binary.LittleEndian.Uint64(t.Data[8*i:]))
+	}
+
+	return values, nil
+}
+
+// Int64 returns the values of a I64 tensor or a tensor of smaller integers as int64.
+func (t *Tensor) Int64() ([]int64, error) {
+	size, ok := safetensorsDTypeSizes[t.DType]
+	if !ok || t.DType == "U64" || (t.DType[0] != 'I' && t.DType[0] != 'U') {
+		return nil, t.typeError("int64")
+	}
+
+	values := make([]int64, len(t.Data)/int(size))
+
+	for i := range values {
+		b := t.Data[i*int(size):]
+
+		switch t.DType {
+		case "I64":
+			values[i] = int64(binary.LittleEndian.Uint64(b))
+		case "I32":
+			values[i] = int64(int32(binary.LittleEndian.Uint32(b)))
+		case "I16":
+			values[i] = int64(int16(binary.LittleEndian.Uint16(b)))
+		case "I8":
+			values[i] = int64(int8(b[0]))
+		case "U32":
+			values[i] = int64(binary.LittleEndian.Uint32(b))
+		case "U16":
+			values[i] = int64(binary.LittleEndian.Uint16(b))
+		case "U8":
+			values[i] = int64(b[0])
+		}
+	}
+
+	return values, nil
+}
+
+// Int32 returns the values of a I32 tensor.
+func (t *Tensor) Int32() ([]int32, error) {
+	if t.DType != "I32" {
+		return nil, t.typeError("int32")
+	}
+
+	values := make([]int32, len(t.Data)/4)
+	for i := range values {
+		values[i] = int32(binary.LittleEndian.Uint32(t.Data[4*i:]))
+	}
+
+	return values, nil
+}
+
+// Uint8 returns the values of a U8 tensor.
+func (t *Tensor) Uint8() ([]uint8, error) {
+	if t.DType != "U8" {
+		return nil, t.typeError("uint8")
+	}
+
+	return append([]uint8{}, t.Data...), nil
+}
+
+// Bool returns the values of a BOOL tensor.
+func (t *Tensor) Bool() ([]bool, error) {
+	if t.DType != "BOOL" {
+		return nil, t.typeError("bool")
+	}
+
+	values := make([]bool, len(t.Data))
+	for i, b := range t.Data {
+		values[i] = b != 0
+	}
+
+	return values, nil
+}
+
+func (t *Tensor) typeError(goType string) error {
+	return fmt.Errorf("tensor %q of dtype %s cannot be read as %s", t.Name, t.DType, goType)
+}
+
+// float16ToFloat32 converts an IEEE 754 half precision float to float32.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := int32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+
+	switch {
+	case exp == 0x1f:
+		// Infinity or NaN
+		return math.Float32frombits(sign | 0xff<<23 | mant<<13)
+	case exp == 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+
+		// Subnormal numbers are normalized
+		exp = 1
+		for mant&0x400 == 0 {
+			mant <<= 1
+			exp--
+		}
+
+		mant &= 0x3ff
+	}
+
+	return math.Float32frombits(sign | uint32(exp+127-15)<<23 | mant<<13)
+}
+
+// float32ToFloat16 converts a float32 to an IEEE 754 half precision float, rounding to nearest even.
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits&0x7fffffff > 0x7f800000:
+		return sign | 0x7e00
+	case exp >= 0x1f:
+		// Infinity and values too large for half precision
+		return sign | 0x7c00
+	case exp <= 0:
+		if exp < -10 {
+			return sign
+		}
+
+		// Subnormal half precision numbers include the implicit leading bit in the mantissa
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := uint32(1) << (shift - 1)
+		rounded := mant >> shift
+
+		if rem := mant & (1<<shift - 1); rem > half || (rem == half && rounded&1 == 1) {
+			rounded++
+		}
+
+		return sign | uint16(rounded)
+	}
+
+	h := uint32(exp)<<10 | mant>>13
+
+	// A carry of the rounding into the exponent yields the next power of two or infinity
+	if rem := mant & 0x1fff; rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+		h++
+	}
+
+	return sign | uint16(h)
+}
+
+// float32ToBFloat16 converts a float32 to bfloat16, rounding to nearest even.
+func float32ToBFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	if bits&0x7fffffff > 0x7f800000 {
+		return uint16(bits>>16) | 0x40
+	}
+
+	return uint16((bits + 0x7fff + (bits>>16)&1) >> 16)
+}
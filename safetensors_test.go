@@ -0,0 +1,110 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSafetensors creates a safetensors file with the header and zeroed data of the specified size.
+func testSafetensors(header string, dataSize int) string {
+	prefix := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	return string(prefix) + header + strings.Repeat("\x00", dataSize)
+}
+
+func TestReadSafetensorsMetadata(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("File", func(t *testing.T) {
+		name := filepath.Join(dir, "model.safetensors")
+		content := testSafetensors(`{"__metadata__":{"format":"pt"},"b":{"dtype":"BF16","shape":[2,3],"data_offsets":[16,28]},"a":{"dtype":"F32","shape":[4],"data_offsets":[0,16]}}`, 28)
+		assert.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+
+		metadata, err := ReadSafetensorsMetadataFile(name)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"format": "pt"}, metadata.Metadata)
+		assert.Equal(t, []string{"a", "b"}, metadata.TensorNames())
+		assert.Equal(t, TensorInfo{DType: "BF16", Shape: []int64{2, 3}, DataOffsets: [2]int64{16, 28}}, metadata.Tensors["b"])
+		assert.Equal(t, map[string]int64{"F32": 4, "BF16": 6}, metadata.ParameterCount)
+		assert.Equal(t, int64(10), metadata.TotalParameters())
+
+		repoMetadata, err := ReadSafetensorsDirMetadata(dir)
+		assert.NoError(t, err)
+		assert.False(t, repoMetadata.Sharded)
+		assert.Equal(t, "model.safetensors", repoMetadata.WeightMap["a"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for header, expected := range map[string]string{
+			`{"a":{"dtype":"F32","shape":[4],"data_offsets":[0,8]}}`: `safetensors: tensor "a": data offsets [0 8] do not match dtype F32 and shape [4]`,
+			`{"a":{"dtype":"X","shape":[],"data_offsets":[0,1]}}`:    `safetensors: tensor "a": unknown dtype "X"`,
+		} {
+			name := filepath.Join(t.TempDir(), "invalid.safetensors")
+			assert.NoError(t, os.WriteFile(name, []byte(testSafetensors(header, 16)), 0o600))
+
+			_, err := ReadSafetensorsMetadataFile(name)
+			assert.EqualError(t, err, expected)
+		}
+
+		name := filepath.Join(t.TempDir(), "invalid.safetensors")
+		assert.NoError(t, os.WriteFile(name, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 0o600))
+
+		_, err := ReadSafetensorsMetadataFile(name)
+		assert.ErrorContains(t, err, "invalid header size")
+	})
+}
+
+func TestSafetensorsMetadata(t *testing.T) {
+	index := `{"metadata":{"total_size":40},"weight_map":{"a":"model-00001-of-00002.safetensors","b":"model-00002-of-00002.safetensors","c":"model-00002-of-00002.safetensors"}}`
+
+	server := newTestHub(t, map[string]hubFile{
+		"model.safetensors.index.json":     {content: index},
+		"model-00001-of-00002.safetensors": {content: testSafetensors(`{"a":{"dtype":"F32","shape":[2,2],"data_offsets":[0,16]}}`, 1<<20), lfs: true},
+		"model-00002-of-00002.safetensors": {content: testSafetensors(`{"b":{"dtype":"F16","shape":[4],"data_offsets":[0,8]},"c":{"dtype":"F32","shape":[4],"data_offsets":[8,24]}}`, 1<<20), lfs: true},
+	})
+	defer server.Close()
+
+	client := NewHubClient("your-token", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	metadata, err := client.SafetensorsMetadata(context.Background(), "org/model", "")
+	assert.NoError(t, err)
+	assert.True(t, metadata.Sharded)
+	assert.Equal(t, float64(40), metadata.Metadata["total_size"])
+	assert.Len(t, metadata.Files, 2)
+	assert.Equal(t, map[string]int64{"F32": 8, "F16": 4}, metadata.ParameterCount)
+	assert.Equal(t, int64(12), metadata.TotalParameters())
+
+	// The length prefix and the header of each shard
+	assert.Equal(t, int32(4), atomic.LoadInt32(&server.rangeRequests))
+
+	fileMetadata, err := client.SafetensorsFileMetadata(context.Background(), "org/model", "model-00002-of-00002.safetensors", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, fileMetadata.TensorNames())
+
+	_, err = client.SafetensorsFileMetadata(context.Background(), "org/model", "missing.safetensors", "")
+	assert.True(t, isNotFound(err))
+}
+
+func TestSafetensorsMetadataSingleFile(t *testing.T) {
+	server := newTestHub(t, map[string]hubFile{
+		"model.safetensors": {content: testSafetensors(`{"a":{"dtype":"I64","shape":[3],"data_offsets":[0,24]}}`, 24), lfs: true},
+	})
+	defer server.Close()
+
+	client := NewHubClient("", func(o *HubClientOptions) {
+		o.Endpoint = server.URL
+	})
+
+	metadata, err := client.SafetensorsMetadata(context.Background(), "org/model", "")
+	assert.NoError(t, err)
+	assert.False(t, metadata.Sharded)
+	assert.Equal(t, int64(3), metadata.TotalParameters())
+}
@@ -0,0 +1,61 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// serverClient sends requests to a self-hosted inference server, such as
+// text-generation-inference or text-embeddings-inference.
+type serverClient struct {
+	transport
+	endpoint string
+}
+
+// newServerClient creates a serverClient for the server at the specified endpoint.
+func newServerClient(endpoint, token string, httpClient HTTPClient) serverClient {
+	return serverClient{
+		transport: transport{
+			httpClient: httpClient,
+			token:      token,
+		},
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+	}
+}
+
+// get sends a GET request to the specified path of the server.
+func (c *serverClient) get(ctx context.Context, path string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.endpoint, path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(httpReq)
+}
+
+// post sends a POST request with the JSON encoded payload to the specified path of the server.
+func (c *serverClient) post(ctx context.Context, path string, payload any) ([]byte, error) {
+	return c.sendJSON(ctx, http.MethodPost, path, payload)
+}
+
+// sendJSON sends a request with the specified method and the JSON encoded payload to the specified path of the server.
+func (c *serverClient) sendJSON(ctx context.Context, method, path string, payload any) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.endpoint, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	return c.do(httpReq)
+}
@@ -0,0 +1,275 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// TabularData represents a table as a map of column names to the values of the column.
+// All columns must have the same number of values, one for each row.
+type TabularData map[string][]string
+
+// NewTabularDataFromCSV reads a table from CSV data. The first record is used as column names.
+func NewTabularDataFromCSV(r io.Reader) (TabularData, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("csv header is required")
+	}
+
+	header := records[0]
+	data := make(TabularData, len(header))
+
+	for _, column := range header {
+		if _, ok := data[column]; ok {
+			return nil, fmt.Errorf("duplicate column %q", column)
+		}
+
+		data[column] = make([]string, 0, len(records)-1)
+	}
+
+	for _, record := range records[1:] {
+		for i, column := range header {
+			data[column] = append(data[column], record[i])
+		}
+	}
+
+	return data, nil
+}
+
+// NewTabularDataFromStructs creates a table from a slice of structs or pointers to structs, with one row
+// for each element. The column names are taken from the `tabular` struct tag or the field name if the
+// tag is missing. Fields tagged with `tabular:"-"` and unexported fields are ignored.
+func NewTabularDataFromStructs(rows any) (TabularData, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("rows must be a slice of structs, got %T", rows)
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rows must be a slice of structs, got %T", rows)
+	}
+
+	type column struct {
+		name  string
+		index int
+	}
+
+	columns := []column{}
+
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("tabular"); ok {
+			if tag == "-" {
+				continue
+			}
+
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		columns = append(columns, column{name: name, index: i})
+	}
+
+	data := make(TabularData, len(columns))
+	for _, c := range columns {
+		data[c.name] = make([]string, 0, v.Len())
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				return nil, fmt.Errorf("row %d is nil", i)
+			}
+
+			elem = elem.Elem()
+		}
+
+		for _, c := range columns {
+			data[c.name] = append(data[c.name], formatTabularValue(elem.Field(c.index)))
+		}
+	}
+
+	return data, nil
+}
+
+// formatTabularValue formats the value of a struct field as table cell. Nil pointers are formatted as empty cell.
+func formatTabularValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// Rows returns the number of rows of the table or an error if the columns have different lengths.
+func (td TabularData) Rows() (int, error) {
+	if len(td) == 0 {
+		return 0, errors.New("table has no columns")
+	}
+
+	rows := -1
+
+	for column, values := range td {
+		if rows == -1 {
+			rows = len(values)
+		} else if len(values) != rows {
+			return 0, fmt.Errorf("column %q has %d values, expected %d", column, len(values), rows)
+		}
+	}
+
+	return rows, nil
+}
+
+// tabularPayload returns the payload of tabular tasks and the number of rows of the table.
+func tabularPayload(data TabularData, options Options) (map[string]any, int, error) {
+	if data == nil {
+		return nil, 0, errors.New("inputs are required")
+	}
+
+	rows, err := data.Rows()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return map[string]any{
+		"inputs":  map[string]any{"data": data},
+		"options": options,
+	}, rows, nil
+}
+
+// Request structure for the tabular classification endpoint
+type TabularClassificationRequest struct {
+	// (Required) The table of features with one row for each prediction.
+	Inputs  TabularData
+	Options Options
+	Model   string
+}
+
+// Response structure for the tabular classification endpoint. It contains the predicted label of each row.
+type TabularClassificationResponse []string
+
+// UnmarshalJSON decodes the predicted labels, which may be strings or numbers depending on the model.
+func (r *TabularClassificationResponse) UnmarshalJSON(data []byte) error {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	labels := make(TabularClassificationResponse, len(raw))
+
+	for i, value := range raw {
+		var label string
+		if err := json.Unmarshal(value, &label); err != nil {
+			var number json.Number
+			if err := json.Unmarshal(value, &number); err != nil {
+				return fmt.Errorf("invalid label %s", value)
+			}
+
+			label = number.String()
+		}
+
+		labels[i] = label
+	}
+
+	*r = labels
+
+	return nil
+}
+
+// TabularClassification classifies the rows of a table using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided table.
+// The response contains one label for each row or an error if the request fails.
+func (ic *InferenceClient) TabularClassification(ctx context.Context, req *TabularClassificationRequest) (TabularClassificationResponse, error) {
+	payload, rows, err := tabularPayload(req.Inputs, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.post(ctx, req.Model, "tabular-classification", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	tabularClassificationResponse := TabularClassificationResponse{}
+	if err := json.Unmarshal(body, &tabularClassificationResponse); err != nil {
+		return nil, err
+	}
+
+	if len(tabularClassificationResponse) != rows {
+		return nil, fmt.Errorf("expected %d predictions, got %d", rows, len(tabularClassificationResponse))
+	}
+
+	return tabularClassificationResponse, nil
+}
+
+// Request structure for the tabular regression endpoint
+type TabularRegressionRequest struct {
+	// (Required) The table of features with one row for each prediction.
+	Inputs  TabularData
+	Options Options
+	Model   string
+}
+
+// Response structure for the tabular regression endpoint. It contains the predicted value of each row.
+type TabularRegressionResponse []float64
+
+// TabularRegression predicts a numeric value for the rows of a table using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided table.
+// The response contains one value for each row or an error if the request fails.
+func (ic *InferenceClient) TabularRegression(ctx context.Context, req *TabularRegressionRequest) (TabularRegressionResponse, error) {
+	payload, rows, err := tabularPayload(req.Inputs, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.post(ctx, req.Model, "tabular-regression", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	tabularRegressionResponse := TabularRegressionResponse{}
+	if err := json.Unmarshal(body, &tabularRegressionResponse); err != nil {
+		return nil, err
+	}
+
+	if len(tabularRegressionResponse) != rows {
+		return nil, fmt.Errorf("expected %d predictions, got %d", rows, len(tabularRegressionResponse))
+	}
+
+	return tabularRegressionResponse, nil
+}
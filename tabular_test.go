@@ -0,0 +1,102 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTabularData(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		data, err := NewTabularDataFromCSV(strings.NewReader("age,city\n42,Berlin\n23,Paris\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, TabularData{"age": {"42", "23"}, "city": {"Berlin", "Paris"}}, data)
+
+		rows, err := data.Rows()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, rows)
+	})
+
+	t.Run("Structs", func(t *testing.T) {
+		type house struct {
+			Rooms  int      `tabular:"rooms"`
+			Area   float64  `tabular:"area"`
+			Garage *bool    `tabular:"garage"`
+			Note   string   `tabular:"-"`
+			Price  *float32 // column name defaults to the field name
+			secret string
+		}
+
+		data, err := NewTabularDataFromStructs([]*house{
+			{Rooms: 3, Area: 72.5, Garage: PTR(true), Note: "ignored", Price: PTR(float32(1.5))},
+			{Rooms: 1, Area: 30},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, TabularData{
+			"rooms":  {"3", "1"},
+			"area":   {"72.5", "30"},
+			"garage": {"true", ""},
+			"Price":  {"1.5", ""},
+		}, data)
+
+		_, err = NewTabularDataFromStructs([]int{1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Misaligned columns", func(t *testing.T) {
+		_, err := TabularData{"a": {"1", "2"}, "b": {"1"}}.Rows()
+		assert.Error(t, err)
+	})
+}
+
+func TestTabularClassification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := struct {
+			Inputs struct {
+				Data map[string][]string `json:"data"`
+			} `json:"inputs"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []string{"1", "2"}, payload.Inputs.Data["a"])
+
+		if r.URL.Path == "/models/regressor" {
+			// A single prediction for two rows
+			_, _ = w.Write([]byte(`[1.5]`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[1, "yes"]`))
+	}))
+	defer server.Close()
+
+	client := NewInferenceClient("your-token", func(o *InferenceClientOptions) {
+		o.InferenceEndpoint = server.URL
+	})
+
+	t.Run("Numeric and string labels", func(t *testing.T) {
+		res, err := client.TabularClassification(context.Background(), &TabularClassificationRequest{
+			Inputs: TabularData{"a": {"1", "2"}},
+			Model:  "model",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, TabularClassificationResponse{"1", "yes"}, res)
+	})
+
+	t.Run("Prediction count mismatch", func(t *testing.T) {
+		_, err := client.TabularRegression(context.Background(), &TabularRegressionRequest{
+			Inputs: TabularData{"a": {"1", "2"}},
+			Model:  "regressor",
+		})
+		assert.EqualError(t, err, "expected 2 predictions, got 1")
+	})
+
+	t.Run("Missing inputs", func(t *testing.T) {
+		_, err := client.TabularRegression(context.Background(), &TabularRegressionRequest{})
+		assert.EqualError(t, err, "inputs are required")
+	})
+}
@@ -0,0 +1,422 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+// TEIClientOptions represents options for the TEIClient.
+type TEIClientOptions struct {
+	HTTPClient HTTPClient
+}
+
+// TEIClient is a client for a text-embeddings-inference server.
+type TEIClient struct {
+	serverClient
+	opts TEIClientOptions
+}
+
+// NewTEIClient creates a new TEIClient instance for the server at the specified endpoint.
+// The token is optional and only required for protected deployments such as Inference Endpoints.
+func NewTEIClient(endpoint, token string, optFns ...func(o *TEIClientOptions)) *TEIClient {
+	opts := TEIClientOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &TEIClient{
+		serverClient: newServerClient(endpoint, token, opts.HTTPClient),
+		opts:         opts,
+	}
+}
+
+// TruncationDirection specifies which side of the inputs is truncated.
+type TruncationDirection string
+
+const (
+	TruncationDirectionLeft  TruncationDirection = "Left"
+	TruncationDirectionRight TruncationDirection = "Right"
+)
+
+// TEIEmbedRequest represents a request for the embed endpoint.
+type TEIEmbedRequest struct {
+	// (Required) The texts to embed.
+	Inputs []string `json:"inputs"`
+
+	// (Default: true). Whether the embeddings are L2 normalized.
+	Normalize *bool `json:"normalize,omitempty"`
+
+	// (Default: false). Whether inputs longer than the maximum sequence length are truncated
+	// instead of rejected.
+	Truncate *bool `json:"truncate,omitempty"`
+
+	// (Default: Right). The side of the inputs that is truncated.
+	TruncationDirection TruncationDirection `json:"truncation_direction,omitempty"`
+
+	// The name of the prompt of the model configuration that is prepended to the inputs.
+	PromptName string `json:"prompt_name,omitempty"`
+
+	// The number of dimensions of the embeddings, for models trained with Matryoshka representation learning.
+	Dimensions *int `json:"dimensions,omitempty"`
+}
+
+// TEIEmbedResponse represents the response of the embed endpoint, one embedding per input.
+type TEIEmbedResponse [][]float32
+
+// Embed computes the pooled embeddings of the inputs.
+func (c *TEIClient) Embed(ctx context.Context, req *TEIEmbedRequest) (TEIEmbedResponse, error) {
+	if len(req.Inputs) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, err := c.post(ctx, "/embed", req)
+	if err != nil {
+		return nil, err
+	}
+
+	embedResponse := TEIEmbedResponse{}
+	if err := json.Unmarshal(body, &embedResponse); err != nil {
+		return nil, err
+	}
+
+	return embedResponse, nil
+}
+
+// TEIEmbedAllRequest represents a request for the embed_all and embed_sparse endpoints.
+type TEIEmbedAllRequest struct {
+	// (Required) The texts to embed.
+	Inputs []string `json:"inputs"`
+
+	// (Default: false). Whether inputs longer than the maximum sequence length are truncated
+	// instead of rejected.
+	Truncate *bool `json:"truncate,omitempty"`
+
+	// (Default: Right). The side of the inputs that is truncated.
+	TruncationDirection TruncationDirection `json:"truncation_direction,omitempty"`
+
+	// The name of the prompt of the model configuration that is prepended to the inputs.
+	PromptName string `json:"prompt_name,omitempty"`
+}
+
+// TEIEmbedAllResponse represents the response of the embed_all endpoint,
+// one embedding per token of each input.
+type TEIEmbedAllResponse [][][]float32
+
+// EmbedAll computes the token embeddings of the inputs without pooling.
+func (c *TEIClient) EmbedAll(ctx context.Context, req *TEIEmbedAllRequest) (TEIEmbedAllResponse, error) {
+	if len(req.Inputs) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, err := c.post(ctx, "/embed_all", req)
+	if err != nil {
+		return nil, err
+	}
+
+	embedAllResponse := TEIEmbedAllResponse{}
+	if err := json.Unmarshal(body, &embedAllResponse); err != nil {
+		return nil, err
+	}
+
+	return embedAllResponse, nil
+}
+
+// TEISparseValue represents a non-zero entry of a sparse embedding.
+type TEISparseValue struct {
+	// The index of the entry, usually a token id of the vocabulary.
+	Index int `json:"index"`
+
+	// The value of the entry.
+	Value float32 `json:"value"`
+}
+
+// TEIEmbedSparseResponse represents the response of the embed_sparse endpoint,
+// one sparse embedding per input.
+type TEIEmbedSparseResponse [][]TEISparseValue
+
+// EmbedSparse computes the sparse embeddings of the inputs, e.g. with SPLADE models.
+func (c *TEIClient) EmbedSparse(ctx context.Context, req *TEIEmbedAllRequest) (TEIEmbedSparseResponse, error) {
+	if len(req.Inputs) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, err := c.post(ctx, "/embed_sparse", req)
+	if err != nil {
+		return nil, err
+	}
+
+	embedSparseResponse := TEIEmbedSparseResponse{}
+	if err := json.Unmarshal(body, &embedSparseResponse); err != nil {
+		return nil, err
+	}
+
+	return embedSparseResponse, nil
+}
+
+// TEIRerankRequest represents a request for the rerank endpoint.
+type TEIRerankRequest struct {
+	// (Required) The query the texts are ranked against.
+	Query string `json:"query"`
+
+	// (Required) The texts to rank.
+	Texts []string `json:"texts"`
+
+	// (Default: false). Whether inputs longer than the maximum sequence length are truncated
+	// instead of rejected.
+	Truncate *bool `json:"truncate,omitempty"`
+
+	// (Default: Right). The side of the inputs that is truncated.
+	TruncationDirection TruncationDirection `json:"truncation_direction,omitempty"`
+
+	// (Default: false). Whether the raw logits are returned instead of sigmoid scores.
+	RawScores *bool `json:"raw_scores,omitempty"`
+
+	// (Default: false). Whether the texts are included in the response.
+	ReturnText *bool `json:"return_text,omitempty"`
+}
+
+// TEIRank represents the score of a ranked text.
+type TEIRank struct {
+	// The index of the text in the request.
+	Index int `json:"index"`
+
+	// The relevance score of the text.
+	Score float64 `json:"score"`
+
+	// The text, if requested with ReturnText.
+	Text string `json:"text,omitempty"`
+}
+
+// TEIRerankResponse represents the response of the rerank endpoint, sorted by descending score.
+type TEIRerankResponse []TEIRank
+
+// Indices returns the indices of the texts in ranked order.
+func (r TEIRerankResponse) Indices() []int {
+	indices := make([]int, len(r))
+	for i, rank := range r {
+		indices[i] = rank.Index
+	}
+
+	return indices
+}
+
+// Rerank ranks the texts by their relevance to the query.
+func (c *TEIClient) Rerank(ctx context.Context, req *TEIRerankRequest) (TEIRerankResponse, error) {
+	if req.Query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	if len(req.Texts) == 0 {
+		return nil, errors.New("texts are required")
+	}
+
+	body, err := c.post(ctx, "/rerank", req)
+	if err != nil {
+		return nil, err
+	}
+
+	rerankResponse := TEIRerankResponse{}
+	if err := json.Unmarshal(body, &rerankResponse); err != nil {
+		return nil, err
+	}
+
+	return rerankResponse, nil
+}
+
+// RerankBatched ranks the texts by their relevance to the query, splitting them into requests
+// of at most batchSize texts. This allows ranking more texts than the max_client_batch_size of
+// the server. The returned indices refer to the texts of the request.
+func (c *TEIClient) RerankBatched(ctx context.Context, req *TEIRerankRequest, batchSize int) (TEIRerankResponse, error) {
+	if len(req.Texts) == 0 {
+		return nil, errors.New("texts are required")
+	}
+
+	if batchSize <= 0 {
+		return nil, errors.New("batchSize must be positive")
+	}
+
+	rerankResponse := make(TEIRerankResponse, 0, len(req.Texts))
+
+	for offset := 0; offset < len(req.Texts); offset += batchSize {
+		end := offset + batchSize
+		if end > len(req.Texts) {
+			end = len(req.Texts)
+		}
+
+		batchReq := *req
+		batchReq.Texts = req.Texts[offset:end]
+
+		ranks, err := c.Rerank(ctx, &batchReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rank := range ranks {
+			rank.Index += offset
+			rerankResponse = append(rerankResponse, rank)
+		}
+	}
+
+	sort.SliceStable(rerankResponse, func(i, j int) bool {
+		return rerankResponse[i].Score > rerankResponse[j].Score
+	})
+
+	return rerankResponse, nil
+}
+
+// TEIPredictInput represents a single text or a text pair to classify.
+type TEIPredictInput struct {
+	// (Required) The text to classify.
+	Text string
+
+	// The second text of a pair, e.g. for natural language inference models.
+	TextPair string
+}
+
+// MarshalJSON encodes the input as a string or, for pairs, as an array of two strings.
+func (i TEIPredictInput) MarshalJSON() ([]byte, error) {
+	if i.TextPair == "" {
+		return json.Marshal(i.Text)
+	}
+
+	return json.Marshal([2]string{i.Text, i.TextPair})
+}
+
+// TEIPredictRequest represents a request for the predict endpoint.
+type TEIPredictRequest struct {
+	// (Required) The inputs to classify.
+	Inputs []TEIPredictInput `json:"inputs"`
+
+	// (Default: false). Whether inputs longer than the maximum sequence length are truncated
+	// instead of rejected.
+	Truncate *bool `json:"truncate,omitempty"`
+
+	// (Default: Right). The side of the inputs that is truncated.
+	TruncationDirection TruncationDirection `json:"truncation_direction,omitempty"`
+
+	// (Default: false). Whether the raw logits are returned instead of softmax scores.
+	RawScores *bool `json:"raw_scores,omitempty"`
+}
+
+// TEIPrediction represents the score of a label.
+type TEIPrediction struct {
+	// The label of the class.
+	Label string `json:"label"`
+
+	// The score of the class.
+	Score float64 `json:"score"`
+}
+
+// TEIPredictResponse represents the response of the predict endpoint,
+// the predictions for each input sorted by descending score.
+type TEIPredictResponse [][]TEIPrediction
+
+// Predict classifies the inputs with a sequence classification model.
+func (c *TEIClient) Predict(ctx context.Context, req *TEIPredictRequest) (TEIPredictResponse, error) {
+	if len(req.Inputs) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, err := c.post(ctx, "/predict", req)
+	if err != nil {
+		return nil, err
+	}
+
+	predictResponse := TEIPredictResponse{}
+	if err := json.Unmarshal(body, &predictResponse); err != nil {
+		return nil, err
+	}
+
+	return predictResponse, nil
+}
+
+// TEITokenizeRequest represents a request for the tokenize endpoint.
+type TEITokenizeRequest struct {
+	// (Required) The texts to tokenize.
+	Inputs []string `json:"inputs"`
+
+	// (Default: true). Whether special tokens are added.
+	AddSpecialTokens *bool `json:"add_special_tokens,omitempty"`
+
+	// The name of the prompt of the model configuration that is prepended to the inputs.
+	PromptName string `json:"prompt_name,omitempty"`
+}
+
+// TEIToken represents a token returned by the tokenize endpoint.
+type TEIToken struct {
+	// The id of the token.
+	ID int `json:"id"`
+
+	// The text of the token.
+	Text string `json:"text"`
+
+	// Whether the token is a special token.
+	Special bool `json:"special"`
+
+	// The start offset of the token within the input, or nil for special tokens.
+	Start *int `json:"start"`
+
+	// The stop offset of the token within the input, or nil for special tokens.
+	Stop *int `json:"stop"`
+}
+
+// TEITokenizeResponse represents the response of the tokenize endpoint, the tokens of each input.
+type TEITokenizeResponse [][]TEIToken
+
+// Tokenize tokenizes the inputs with the tokenizer of the served model.
+func (c *TEIClient) Tokenize(ctx context.Context, req *TEITokenizeRequest) (TEITokenizeResponse, error) {
+	if len(req.Inputs) == 0 {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, err := c.post(ctx, "/tokenize", req)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenizeResponse := TEITokenizeResponse{}
+	if err := json.Unmarshal(body, &tokenizeResponse); err != nil {
+		return nil, err
+	}
+
+	return tokenizeResponse, nil
+}
+
+// TEIDecodeRequest represents a request for the decode endpoint.
+type TEIDecodeRequest struct {
+	// (Required) The token ids of each sequence to decode.
+	IDs [][]int `json:"ids"`
+
+	// (Default: true). Whether special tokens are removed from the output.
+	SkipSpecialTokens *bool `json:"skip_special_tokens,omitempty"`
+}
+
+// TEIDecodeResponse represents the response of the decode endpoint, one text per sequence.
+type TEIDecodeResponse []string
+
+// Decode decodes token ids into texts.
+func (c *TEIClient) Decode(ctx context.Context, req *TEIDecodeRequest) (TEIDecodeResponse, error) {
+	if len(req.IDs) == 0 {
+		return nil, errors.New("ids are required")
+	}
+
+	body, err := c.post(ctx, "/decode", req)
+	if err != nil {
+		return nil, err
+	}
+
+	decodeResponse := TEIDecodeResponse{}
+	if err := json.Unmarshal(body, &decodeResponse); err != nil {
+		return nil, err
+	}
+
+	return decodeResponse, nil
+}
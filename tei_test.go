@@ -0,0 +1,79 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTEIClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/embed":
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "query", req["prompt_name"])
+			assert.Equal(t, "Left", req["truncation_direction"])
+
+			_, _ = w.Write([]byte(`[[0.1,0.2],[0.3,0.4]]`))
+		case "/rerank":
+			req := TEIRerankRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			// Scores the texts by their length to produce a deterministic ranking.
+			ranks := TEIRerankResponse{}
+			for i, text := range req.Texts {
+				ranks = append(ranks, TEIRank{Index: i, Score: float64(len(text))})
+			}
+
+			_ = json.NewEncoder(w).Encode(ranks)
+		case "/predict":
+			body := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []any{"a", []any{"b", "c"}}, body["inputs"])
+
+			_, _ = w.Write([]byte(`[[{"label":"positive","score":0.9}],[{"label":"entailment","score":0.8}]]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTEIClient(server.URL, "")
+
+	t.Run("Embed", func(t *testing.T) {
+		embeddings, err := client.Embed(context.Background(), &TEIEmbedRequest{
+			Inputs:              []string{"a", "b"},
+			PromptName:          "query",
+			TruncationDirection: TruncationDirectionLeft,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, TEIEmbedResponse{{0.1, 0.2}, {0.3, 0.4}}, embeddings)
+	})
+
+	t.Run("RerankBatched", func(t *testing.T) {
+		ranks, err := client.RerankBatched(context.Background(), &TEIRerankRequest{
+			Query: "query",
+			Texts: []string{"aa", "a", "aaaa", "aaa", "aaaaa"},
+		}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{4, 2, 3, 0, 1}, ranks.Indices())
+	})
+
+	t.Run("Predict", func(t *testing.T) {
+		predictions, err := client.Predict(context.Background(), &TEIPredictRequest{
+			Inputs: []TEIPredictInput{{Text: "a"}, {Text: "b", TextPair: "c"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "entailment", predictions[1][0].Label)
+	})
+
+	t.Run("Missing query", func(t *testing.T) {
+		_, err := client.Rerank(context.Background(), &TEIRerankRequest{Texts: []string{"a"}})
+		assert.EqualError(t, err, "query is required")
+	})
+}
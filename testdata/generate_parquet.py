@@ -0,0 +1,25 @@
+"""Writes the parquet fixture of TestParquetReaderPyarrow.
+
+Run with pyarrow installed from the root of the repository:
+
+    python3 testdata/generate_parquet.py
+"""
+
+import pyarrow as pa
+import pyarrow.parquet as pq
+
+table = pa.table(
+    {
+        "id": pa.array([1, 2, 3], type=pa.int64()),
+        "label": pa.array(["positive", "negative", "positive"], type=pa.string()),
+        "score": pa.array([0.5, None, 1.25], type=pa.float64()),
+        "tags": pa.array([["a", "b"], [], None], type=pa.list_(pa.string())),
+    }
+)
+
+pq.write_table(
+    table,
+    "testdata/pyarrow.parquet",
+    compression="snappy",
+    use_dictionary=True,
+)
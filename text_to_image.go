@@ -0,0 +1,162 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register the JPEG decoder for generated images
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Used with TextToImageRequest
+type TextToImageParameters struct {
+	// (Default: None). A prompt to guide what should not be included in the image.
+	NegativePrompt string `json:"negative_prompt,omitempty"`
+
+	// (Default: None). Integer. The height in pixels of the generated image.
+	Height *int `json:"height,omitempty"`
+
+	// (Default: None). Integer. The width in pixels of the generated image.
+	Width *int `json:"width,omitempty"`
+
+	// (Default: None). Integer. The number of denoising steps. More steps usually lead to a higher
+	// quality image at the expense of slower inference.
+	NumInferenceSteps *int `json:"num_inference_steps,omitempty"`
+
+	// (Default: None). Float. A higher guidance scale encourages images that are closely linked to
+	// the prompt at the expense of lower image quality.
+	GuidanceScale *float64 `json:"guidance_scale,omitempty"`
+
+	// (Default: None). Integer. The seed of the random number generator, for reproducible images.
+	Seed *int64 `json:"seed,omitempty"`
+
+	// (Default: None). The scheduler to use for the diffusion process, e.g. DPMSolverMultistepScheduler.
+	Scheduler string `json:"scheduler,omitempty"`
+}
+
+// Request structure for the text-to-image endpoint
+type TextToImageRequest struct {
+	// (Required) The prompt to generate an image from.
+	Inputs     string                `json:"inputs"`
+	Parameters TextToImageParameters `json:"parameters,omitempty"`
+	Options    Options               `json:"options,omitempty"`
+	Model      string                `json:"-"`
+}
+
+// Response structure for the text-to-image endpoint
+type TextToImageResponse struct {
+	// The generated image, which is nil if no decoder is registered for its format, e.g. for webp
+	// unless golang.org/x/image/webp is imported. The encoded image is available in Data.
+	Image image.Image
+
+	// The encoded image as returned by the model.
+	Data []byte
+
+	// The content type of the encoded image, e.g. image/jpeg.
+	ContentType string
+
+	// The seed used for the generation, if set in the request.
+	Seed *int64
+}
+
+// TextToImage generates an image from a text prompt using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided prompt.
+// The response contains the generated image or an error if the request fails.
+func (ic *InferenceClient) TextToImage(ctx context.Context, req *TextToImageRequest) (*TextToImageResponse, error) {
+	if req.Inputs == "" {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, contentType, err := ic.postForBinary(ctx, req.Model, "text-to-image", "", req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The encoded image is returned without decoding it if no decoder is registered for its format
+	img, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil && !errors.Is(err, image.ErrFormat) {
+		return nil, err
+	}
+
+	if contentType == "" || !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(body)
+	}
+
+	return &TextToImageResponse{
+		Image:       img,
+		Data:        body,
+		ContentType: contentType,
+		Seed:        req.Parameters.Seed,
+	}, nil
+}
+
+// TextToImageBatch generates one image per seed from the same prompt, so the images only differ by the
+// variation introduced by the seeds. The images are generated one after another.
+func (ic *InferenceClient) TextToImageBatch(ctx context.Context, req *TextToImageRequest, seeds []int64) ([]*TextToImageResponse, error) {
+	if len(seeds) == 0 {
+		return nil, errors.New("seeds are required")
+	}
+
+	responses := make([]*TextToImageResponse, 0, len(seeds))
+
+	for _, seed := range seeds {
+		seedReq := *req
+		seedReq.Parameters.Seed = PTR(seed)
+
+		res, err := ic.TextToImage(ctx, &seedReq)
+		if err != nil {
+			return nil, fmt.Errorf("seed %d: %w", seed, err)
+		}
+
+		responses = append(responses, res)
+	}
+
+	return responses, nil
+}
+
+// WriteTextToImageResults writes the encoded images to the directory, which is created if it does not exist.
+// The files are named by their index and seed, e.g. image-0-seed-42.jpg. It returns the paths of the files.
+func WriteTextToImageResults(dir string, results []*TextToImageResponse) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(results))
+
+	for i, res := range results {
+		name := fmt.Sprintf("image-%d", i)
+		if res.Seed != nil {
+			name = fmt.Sprintf("%s-seed-%d", name, *res.Seed)
+		}
+
+		path := filepath.Join(dir, name+imageExtension(res.ContentType))
+		if err := os.WriteFile(path, res.Data, 0o600); err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// imageExtension returns the file extension for the image content type.
+func imageExtension(contentType string) string {
+	switch strings.TrimSpace(strings.Split(contentType, ";")[0]) {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ".img"
+	}
+}
@@ -0,0 +1,148 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Used with TextToSpeechParameters
+type TextToSpeechGenerateKwargs struct {
+	// (Default: None). Bool. Whether to use sampling instead of greedy decoding.
+	DoSample *bool `json:"do_sample,omitempty"`
+
+	// (Default: None). Integer. The maximum number of tokens to generate.
+	MaxNewTokens *int `json:"max_new_tokens,omitempty"`
+
+	// (Default: 1.0). Float. The temperature of the sampling operation.
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// (Default: None). Integer to define the top tokens considered within the sample operation.
+	TopK *int `json:"top_k,omitempty"`
+
+	// (Default: None). Float to define the tokens that are within the sample operation.
+	TopP *float64 `json:"top_p,omitempty"`
+}
+
+// Used with TextToSpeechRequest
+type TextToSpeechParameters struct {
+	// Parameters passed to the generate method of the model.
+	GenerateKwargs *TextToSpeechGenerateKwargs `json:"generate_kwargs,omitempty"`
+}
+
+// Request structure for the text-to-speech endpoint
+type TextToSpeechRequest struct {
+	// (Required) The text to synthesize.
+	Inputs     string                 `json:"inputs"`
+	Parameters TextToSpeechParameters `json:"parameters,omitempty"`
+	Options    Options                `json:"options,omitempty"`
+	Model      string                 `json:"-"`
+}
+
+// Response structure for the text-to-speech endpoint
+type TextToSpeechResponse struct {
+	// The synthesized audio.
+	Audio []byte
+
+	// The content type of the audio, e.g. audio/wav.
+	ContentType string
+
+	// The sampling rate of the audio, or 0 if it can not be determined from the audio format.
+	SamplingRate int
+}
+
+// Decode decodes WAV audio, which TextToSpeech requests. Other audio formats, e.g. FLAC returned by
+// deployments that ignore the requested format, are not supported.
+func (r *TextToSpeechResponse) Decode() (*Audio, error) {
+	if !isWAV(r.Audio) {
+		return nil, fmt.Errorf("decoding %s audio is not supported", r.ContentType)
+	}
+
+	return DecodeWAV(bytes.NewReader(r.Audio))
+}
+
+// WriteFile writes the audio to the named file.
+func (r *TextToSpeechResponse) WriteFile(name string) error {
+	return os.WriteFile(name, r.Audio, 0o600)
+}
+
+// TextToSpeech performs text-to-speech synthesis using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided text and requests WAV audio.
+// The response contains the audio or an error if the request fails.
+func (ic *InferenceClient) TextToSpeech(ctx context.Context, req *TextToSpeechRequest) (*TextToSpeechResponse, error) {
+	if req.Inputs == "" {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, contentType, err := ic.postForBinary(ctx, req.Model, "text-to-speech", "audio/wav", req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Some deployments return the waveform as JSON instead of encoded audio
+	if strings.HasPrefix(contentType, "application/json") {
+		return decodeTextToSpeechJSON(body)
+	}
+
+	return &TextToSpeechResponse{
+		Audio:        body,
+		ContentType:  contentType,
+		SamplingRate: detectSamplingRate(body),
+	}, nil
+}
+
+// decodeTextToSpeechJSON decodes a waveform returned as JSON and encodes it as WAV.
+func decodeTextToSpeechJSON(body []byte) (*TextToSpeechResponse, error) {
+	waveform := struct {
+		Audio        json.RawMessage `json:"audio"`
+		SamplingRate int             `json:"sampling_rate"`
+	}{}
+	if err := json.Unmarshal(body, &waveform); err != nil {
+		return nil, err
+	}
+
+	// The waveform may be batched with a batch size of one
+	samples := []float32{}
+	if err := json.Unmarshal(waveform.Audio, &samples); err != nil {
+		batch := [][]float32{}
+		if err := json.Unmarshal(waveform.Audio, &batch); err != nil || len(batch) != 1 {
+			return nil, errors.New("unexpected text-to-speech response")
+		}
+
+		samples = batch[0]
+	}
+
+	wav := &bytes.Buffer{}
+	if err := (&Audio{SampleRate: waveform.SamplingRate, Samples: samples}).EncodeWAV(wav); err != nil {
+		return nil, err
+	}
+
+	return &TextToSpeechResponse{
+		Audio:        wav.Bytes(),
+		ContentType:  "audio/wav",
+		SamplingRate: waveform.SamplingRate,
+	}, nil
+}
+
+// detectSamplingRate reads the sampling rate from the header of WAV or FLAC audio.
+func detectSamplingRate(audio []byte) int {
+	switch {
+	case isWAV(audio) && len(audio) >= 28:
+		return int(binary.LittleEndian.Uint32(audio[24:28]))
+	case bytes.HasPrefix(audio, []byte("fLaC")) && len(audio) >= 21:
+		// The sample rate is stored in 20 bits of the STREAMINFO block following the marker
+		return int(audio[18])<<12 | int(audio[19])<<4 | int(audio[20])>>4
+	default:
+		return 0
+	}
+}
+
+// isWAV reports whether the data starts with a WAV header.
+func isWAV(data []byte) bool {
+	return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE"
+}
@@ -0,0 +1,234 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// TGIClientOptions represents options for the TGIClient.
+type TGIClientOptions struct {
+	HTTPClient HTTPClient
+}
+
+// TGIClient is a client for the management endpoints of a text-generation-inference server.
+type TGIClient struct {
+	serverClient
+	opts TGIClientOptions
+}
+
+// NewTGIClient creates a new TGIClient instance for the server at the specified endpoint.
+// The token is optional and only required for protected deployments such as Inference Endpoints.
+func NewTGIClient(endpoint, token string, optFns ...func(o *TGIClientOptions)) *TGIClient {
+	opts := TGIClientOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &TGIClient{
+		serverClient: newServerClient(endpoint, token, opts.HTTPClient),
+		opts:         opts,
+	}
+}
+
+// TGIInfo represents the response of the info endpoint.
+type TGIInfo struct {
+	// The id of the served model.
+	ModelID string `json:"model_id"`
+
+	// The commit sha of the served model.
+	ModelSHA string `json:"model_sha,omitempty"`
+
+	// The data type of the model weights, e.g. torch.float16.
+	ModelDType string `json:"model_dtype"`
+
+	// The device type the model runs on, e.g. cuda.
+	ModelDeviceType string `json:"model_device_type"`
+
+	// The pipeline tag of the served model.
+	ModelPipelineTag string `json:"model_pipeline_tag,omitempty"`
+
+	// The maximum number of concurrent requests the router accepts.
+	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+
+	// The maximum value of the best_of parameter.
+	MaxBestOf int `json:"max_best_of"`
+
+	// The maximum number of stop sequences per request.
+	MaxStopSequences int `json:"max_stop_sequences"`
+
+	// The maximum number of input tokens per request.
+	MaxInputTokens int `json:"max_input_tokens"`
+
+	// The maximum number of input and generated tokens per request.
+	MaxTotalTokens int `json:"max_total_tokens"`
+
+	// The maximum number of tokens of a batch (only reported by older servers).
+	MaxBatchTotalTokens int `json:"max_batch_total_tokens,omitempty"`
+
+	// The maximum batch size (optional).
+	MaxBatchSize int `json:"max_batch_size,omitempty"`
+
+	// The number of validation workers.
+	ValidationWorkers int `json:"validation_workers"`
+
+	// The maximum number of inputs per client request.
+	MaxClientBatchSize int `json:"max_client_batch_size"`
+
+	// The name of the router.
+	Router string `json:"router"`
+
+	// The version of the server.
+	Version string `json:"version"`
+
+	// The git sha of the server build.
+	SHA string `json:"sha,omitempty"`
+
+	// The docker label of the server image.
+	DockerLabel string `json:"docker_label,omitempty"`
+
+	// Older servers report max_input_length instead of max_input_tokens.
+	MaxInputLength int `json:"max_input_length,omitempty"`
+}
+
+// Info retrieves information about the served model and the server limits.
+func (c *TGIClient) Info(ctx context.Context) (*TGIInfo, error) {
+	body, err := c.get(ctx, "/info")
+	if err != nil {
+		return nil, err
+	}
+
+	info := TGIInfo{}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, err
+	}
+
+	if info.MaxInputTokens == 0 {
+		info.MaxInputTokens = info.MaxInputLength
+	}
+
+	return &info, nil
+}
+
+// Health checks whether the server is able to serve requests.
+// It returns nil if the server is healthy or an error otherwise.
+func (c *TGIClient) Health(ctx context.Context) error {
+	_, err := c.get(ctx, "/health")
+
+	return err
+}
+
+// TGIToken represents a token returned by the tokenize endpoints.
+type TGIToken struct {
+	// The id of the token.
+	ID int `json:"id"`
+
+	// The text of the token.
+	Text string `json:"text"`
+
+	// The start offset of the token within the input.
+	Start int `json:"start"`
+
+	// The stop offset of the token within the input.
+	Stop int `json:"stop"`
+}
+
+// TGITokenizeRequest represents a request for the tokenize endpoint.
+type TGITokenizeRequest struct {
+	// (Required) The text to tokenize.
+	Inputs string `json:"inputs"`
+
+	// Generation parameters, which may influence the tokenization (e.g. truncation).
+	Parameters TextGenerationParameters `json:"parameters,omitempty"`
+}
+
+// TGITokenizeResponse represents the response of the tokenize endpoint.
+type TGITokenizeResponse []TGIToken
+
+// Tokenize tokenizes the inputs with the tokenizer of the served model.
+func (c *TGIClient) Tokenize(ctx context.Context, req *TGITokenizeRequest) (TGITokenizeResponse, error) {
+	if req.Inputs == "" {
+		return nil, errors.New("inputs are required")
+	}
+
+	body, err := c.post(ctx, "/tokenize", req)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenizeResponse := TGITokenizeResponse{}
+	if err := json.Unmarshal(body, &tokenizeResponse); err != nil {
+		return nil, err
+	}
+
+	return tokenizeResponse, nil
+}
+
+// TGIChatMessage represents a message of a chat.
+type TGIChatMessage struct {
+	// The role of the author of the message, e.g. system, user or assistant.
+	Role string `json:"role"`
+
+	// The content of the message.
+	Content string `json:"content"`
+}
+
+// TGIChatTokenizeRequest represents a request for the chat_tokenize endpoint.
+type TGIChatTokenizeRequest struct {
+	// (Required) The messages of the chat.
+	Messages []TGIChatMessage `json:"messages"`
+
+	// The model name, ignored by the server but part of the chat completion schema.
+	Model string `json:"model,omitempty"`
+}
+
+// TGIChatTokenizeResponse represents the response of the chat_tokenize endpoint.
+type TGIChatTokenizeResponse struct {
+	// The tokens of the templated text.
+	TokenizeResponse []TGIToken `json:"tokenize_response"`
+
+	// The chat rendered with the chat template of the served model.
+	TemplatedText string `json:"templated_text"`
+}
+
+// ChatTokenize applies the chat template of the served model and tokenizes the result.
+func (c *TGIClient) ChatTokenize(ctx context.Context, req *TGIChatTokenizeRequest) (*TGIChatTokenizeResponse, error) {
+	if len(req.Messages) == 0 {
+		return nil, errors.New("messages are required")
+	}
+
+	// The request is copied to not modify the request of the caller
+	chatTokenizeReq := *req
+	if chatTokenizeReq.Model == "" {
+		chatTokenizeReq.Model = "tgi"
+	}
+
+	body, err := c.post(ctx, "/chat_tokenize", &chatTokenizeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	chatTokenizeResponse := TGIChatTokenizeResponse{}
+	if err := json.Unmarshal(body, &chatTokenizeResponse); err != nil {
+		return nil, err
+	}
+
+	return &chatTokenizeResponse, nil
+}
+
+// Metrics retrieves and parses the Prometheus metrics of the server.
+func (c *TGIClient) Metrics(ctx context.Context) (Metrics, error) {
+	body, err := c.get(ctx, "/metrics")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMetrics(bytes.NewReader(body))
+}
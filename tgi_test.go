@@ -0,0 +1,116 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTGIClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/info":
+			_, _ = w.Write([]byte(`{"model_id":"bigscience/bloom-560m","model_dtype":"torch.float16","max_input_length":1024,"max_total_tokens":2048,"version":"1.4.0"}`))
+		case "/health":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"unhealthy"}`))
+		case "/tokenize":
+			assert.Equal(t, "Bearer your-token", r.Header.Get("Authorization"))
+
+			req := TGITokenizeRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Hello", req.Inputs)
+
+			_, _ = w.Write([]byte(`[{"id":15043,"text":"Hello","start":0,"stop":5}]`))
+		case "/chat_tokenize":
+			req := TGIChatTokenizeRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "tgi", req.Model)
+
+			_, _ = w.Write([]byte(`{"tokenize_response":[{"id":15043,"text":"Hello","start":0,"stop":5}],"templated_text":"Hello"}`))
+		case "/metrics":
+			_, _ = w.Write([]byte(`# HELP tgi_queue_size Queue size
+# TYPE tgi_queue_size gauge
+tgi_queue_size 3
+# TYPE tgi_request_duration histogram
+tgi_request_duration_bucket{le="0.5"} 1
+tgi_request_duration_bucket{le="+Inf"} 2
+tgi_request_duration_sum 1.5
+tgi_request_duration_count 2
+`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTGIClient(server.URL, "your-token")
+
+	t.Run("Info", func(t *testing.T) {
+		info, err := client.Info(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "bigscience/bloom-560m", info.ModelID)
+		assert.Equal(t, 1024, info.MaxInputTokens)
+		assert.Equal(t, 2048, info.MaxTotalTokens)
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		err := client.Health(context.Background())
+		assert.EqualError(t, err, "huggingfaces error: unhealthy")
+
+		httpErr := &HTTPError{}
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	})
+
+	t.Run("Tokenize", func(t *testing.T) {
+		tokens, err := client.Tokenize(context.Background(), &TGITokenizeRequest{Inputs: "Hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, TGITokenizeResponse{{ID: 15043, Text: "Hello", Start: 0, Stop: 5}}, tokens)
+	})
+
+	t.Run("ChatTokenize", func(t *testing.T) {
+		req := &TGIChatTokenizeRequest{Messages: []TGIChatMessage{{Role: "user", Content: "Hello"}}}
+
+		res, err := client.ChatTokenize(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", res.TemplatedText)
+		assert.Len(t, res.TokenizeResponse, 1)
+		assert.Empty(t, req.Model)
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		metrics, err := client.Metrics(context.Background())
+		assert.NoError(t, err)
+
+		queueSize, ok := metrics.Value("tgi_queue_size", nil)
+		assert.True(t, ok)
+		assert.Equal(t, 3.0, queueSize)
+
+		family := metrics["tgi_request_duration"]
+		assert.Equal(t, MetricTypeHistogram, family.Type)
+		assert.Len(t, family.Samples, 4)
+
+		count, ok := metrics.Value("tgi_request_duration_bucket", map[string]string{"le": "+Inf"})
+		assert.True(t, ok)
+		assert.Equal(t, 2.0, count)
+	})
+}
+
+func TestParseMetrics(t *testing.T) {
+	metrics, err := ParseMetrics(strings.NewReader(`tgi_batch_current_size{model="a\"b"} 4 1700000000000`))
+	assert.NoError(t, err)
+
+	sample := metrics["tgi_batch_current_size"].Samples[0]
+	assert.Equal(t, map[string]string{"model": `a"b`}, sample.Labels)
+	assert.Equal(t, 4.0, sample.Value)
+	assert.Equal(t, int64(1700000000000), sample.Timestamp)
+
+	_, err = ParseMetrics(strings.NewReader(`tgi_batch_current_size{model="a"`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// Used with VideoClassificationRequest
+type VideoClassificationParameters struct {
+	// (Default: None). Integer. The number of frames sampled from the video to run the classification on.
+	// Defaults to the number of frames expected by the model.
+	NumFrames *int `json:"num_frames,omitempty"`
+
+	// (Default: 1). Integer. The sampling rate used to select frames from the video.
+	FrameSamplingRate *int `json:"frame_sampling_rate,omitempty"`
+
+	// (Default: 5). Integer. The number of top labels to return.
+	TopK *int `json:"top_k,omitempty"`
+
+	// (Default: softmax). The function applied to the model outputs to retrieve the scores,
+	// one of sigmoid, softmax or none.
+	FunctionToApply string `json:"function_to_apply,omitempty"`
+}
+
+// Request structure for the video classification endpoint
+type VideoClassificationRequest struct {
+	// (Required) The video to classify. Either Inputs or Filename must be set.
+	Inputs io.Reader
+
+	// The path of the video file to classify, used if Inputs is nil.
+	Filename string
+
+	// The content type of the video, e.g. video/mp4. Detected from the data if empty.
+	ContentType string
+
+	Parameters VideoClassificationParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the video classification endpoint
+type VideoClassificationResponse []struct {
+	// The label for the class (model specific).
+	Label string `json:"label"`
+
+	// A float that represents how likely it is that the video belongs to this class.
+	Score float64 `json:"score"`
+}
+
+// VideoClassification performs video classification using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the provided video.
+// The response contains the labels with their scores or an error if the request fails.
+func (ic *InferenceClient) VideoClassification(ctx context.Context, req *VideoClassificationRequest) (VideoClassificationResponse, error) {
+	data, contentType, err := readBinaryInputWithContentType(req.Inputs, req.Filename, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "video-classification", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	videoClassificationResponse := VideoClassificationResponse{}
+	if err := json.Unmarshal(body, &videoClassificationResponse); err != nil {
+		return nil, err
+	}
+
+	return videoClassificationResponse, nil
+}
@@ -0,0 +1,74 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Used with VisualQuestionAnsweringRequest
+type VisualQuestionAnsweringInputs struct {
+	// (Required) The image the question is about.
+	Image ImageInput
+
+	// (Required) The question about the image.
+	Question string
+}
+
+// Used with VisualQuestionAnsweringRequest
+type VisualQuestionAnsweringParameters struct {
+	// (Default: 1). Integer. The number of answers to return.
+	TopK *int `json:"top_k,omitempty"`
+}
+
+// Request structure for the visual question answering endpoint
+type VisualQuestionAnsweringRequest struct {
+	// (Required)
+	Inputs     VisualQuestionAnsweringInputs
+	Parameters VisualQuestionAnsweringParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the visual question answering endpoint
+type VisualQuestionAnsweringResponse []struct {
+	// The answer to the question.
+	Answer string `json:"answer"`
+
+	// A float that represents how likely that the answer is correct.
+	Score float64 `json:"score"`
+}
+
+// VisualQuestionAnswering answers a question about an image using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the base64 encoded image and the question.
+// The response contains the answers or an error if the request fails.
+func (ic *InferenceClient) VisualQuestionAnswering(ctx context.Context, req *VisualQuestionAnsweringRequest) (VisualQuestionAnsweringResponse, error) {
+	if req.Inputs.Question == "" {
+		return nil, errors.New("question is required")
+	}
+
+	data, _, err := ic.readImageInput(ctx, req.Inputs.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.post(ctx, req.Model, "visual-question-answering", map[string]any{
+		"inputs": map[string]string{
+			"image":    base64.StdEncoding.EncodeToString(data),
+			"question": req.Inputs.Question,
+		},
+		"parameters": req.Parameters,
+		"options":    req.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	visualQuestionAnsweringResponse := VisualQuestionAnsweringResponse{}
+	if err := json.Unmarshal(body, &visualQuestionAnsweringResponse); err != nil {
+		return nil, err
+	}
+
+	return visualQuestionAnsweringResponse, nil
+}
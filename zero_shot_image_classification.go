@@ -0,0 +1,62 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Used with ZeroShotImageClassificationRequest
+type ZeroShotImageClassificationParameters struct {
+	// (Required) The candidate labels for the image.
+	CandidateLabels []string `json:"candidate_labels"`
+
+	// (Default: "This is a photo of {}."). The sentence used in conjunction with the candidate labels
+	// to attempt the image classification by replacing the placeholder with the candidate labels.
+	HypothesisTemplate string `json:"hypothesis_template,omitempty"`
+}
+
+// Request structure for the zero-shot image classification endpoint
+type ZeroShotImageClassificationRequest struct {
+	// (Required) The image to classify.
+	Inputs ImageInput
+	// (Required)
+	Parameters ZeroShotImageClassificationParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the zero-shot image classification endpoint
+type ZeroShotImageClassificationResponse []struct {
+	// The candidate label.
+	Label string `json:"label"`
+
+	// A float that represents how likely it is that the image belongs to this class.
+	Score float64 `json:"score"`
+}
+
+// ZeroShotImageClassification performs zero-shot image classification using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the base64 encoded image and
+// the candidate labels. The response contains the labels with their scores or an error if the request fails.
+func (ic *InferenceClient) ZeroShotImageClassification(ctx context.Context, req *ZeroShotImageClassificationRequest) (ZeroShotImageClassificationResponse, error) {
+	if len(req.Parameters.CandidateLabels) == 0 {
+		return nil, errors.New("candidateLabels are required")
+	}
+
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "zero-shot-image-classification", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	zeroShotImageClassificationResponse := ZeroShotImageClassificationResponse{}
+	if err := json.Unmarshal(body, &zeroShotImageClassificationResponse); err != nil {
+		return nil, err
+	}
+
+	return zeroShotImageClassificationResponse, nil
+}
@@ -0,0 +1,58 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Used with ZeroShotObjectDetectionRequest
+type ZeroShotObjectDetectionParameters struct {
+	// (Required) The candidate labels of the objects to detect.
+	CandidateLabels []string `json:"candidate_labels"`
+
+	// (Default: 0.1). Float. The probability necessary to make a prediction.
+	Threshold *float64 `json:"threshold,omitempty"`
+
+	// (Default: None). Integer. The number of top detections to return.
+	TopK *int `json:"top_k,omitempty"`
+}
+
+// Request structure for the zero-shot object detection endpoint
+type ZeroShotObjectDetectionRequest struct {
+	// (Required) The image to detect objects in.
+	Inputs ImageInput
+	// (Required)
+	Parameters ZeroShotObjectDetectionParameters
+	Options    Options
+	Model      string
+}
+
+// Response structure for the zero-shot object detection endpoint
+type ZeroShotObjectDetectionResponse = ObjectDetectionResponse
+
+// ZeroShotObjectDetection performs zero-shot object detection using the specified model.
+// It sends a POST request to the Hugging Face inference endpoint with the base64 encoded image and
+// the candidate labels. The response contains the detected objects or an error if the request fails.
+func (ic *InferenceClient) ZeroShotObjectDetection(ctx context.Context, req *ZeroShotObjectDetectionRequest) (ZeroShotObjectDetectionResponse, error) {
+	if len(req.Parameters.CandidateLabels) == 0 {
+		return nil, errors.New("candidateLabels are required")
+	}
+
+	data, contentType, err := ic.readImageInput(ctx, req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ic.postBinary(ctx, req.Model, "zero-shot-object-detection", data, contentType, req.Parameters, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	zeroShotObjectDetectionResponse := ZeroShotObjectDetectionResponse{}
+	if err := json.Unmarshal(body, &zeroShotObjectDetectionResponse); err != nil {
+		return nil, err
+	}
+
+	return zeroShotObjectDetectionResponse, nil
+}